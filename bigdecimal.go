@@ -0,0 +1,246 @@
+package filter
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// BigDecimal is an arbitrary-precision decimal number, its value is
+// Unscaled * 10^(-Scale).
+type BigDecimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
+type RoundingMode uint
+
+// rounding modes
+const (
+	RoundHalfUp   RoundingMode = iota // round half away from zero
+	RoundHalfDown                     // round half towards zero
+	RoundHalfEven                     // round half to even (banker's rounding)
+	RoundUp                           // round away from zero
+	RoundDown                         // round towards zero (truncate)
+	RoundCeiling                      // round towards positive infinity
+	RoundFloor                        // round towards negative infinity
+)
+
+var errInvalidDecimal = errors.New("invalid decimal")
+
+var bigTen = big.NewInt(10)
+
+// maxDecimalScale is the largest absolute exponent and scale of a parsed
+// decimal, larger values would make scaling arbitrarily expensive.
+const maxDecimalScale = 1000
+
+// ParseBigDecimal parse a decimal string like "-12.345" or "1.5e3", the
+// exponent and the scale must not exceed maxDecimalScale.
+func ParseBigDecimal(s string) (*BigDecimal, error) {
+	mantissa, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		mantissa = s[:i]
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil || e > maxDecimalScale || e < -maxDecimalScale {
+			return nil, errInvalidDecimal
+		}
+		exp = e
+	}
+
+	sign := ""
+	if mantissa != "" && (mantissa[0] == '+' || mantissa[0] == '-') {
+		sign = mantissa[:1]
+		mantissa = mantissa[1:]
+	}
+
+	intPart, fracPart := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		intPart = mantissa[:i]
+		fracPart = mantissa[i+1:]
+	}
+	if intPart == "" && fracPart == "" {
+		return nil, errInvalidDecimal
+	}
+	for _, c := range intPart + fracPart {
+		if c < '0' || c > '9' {
+			return nil, errInvalidDecimal
+		}
+	}
+
+	scale := len(fracPart) - exp
+	if scale > maxDecimalScale || scale < -maxDecimalScale {
+		return nil, errInvalidDecimal
+	}
+	unscaled, ok := new(big.Int).SetString(sign+intPart+fracPart, 10)
+	if !ok {
+		return nil, errInvalidDecimal
+	}
+	return newBigDecimal(unscaled, scale), nil
+}
+
+// NewBigDecimal return a decimal from an int64 value and a scale.
+func NewBigDecimal(unscaled int64, scale int) *BigDecimal {
+	return newBigDecimal(big.NewInt(unscaled), scale)
+}
+
+// newBigDecimal normalize the negative scale to zero.
+func newBigDecimal(unscaled *big.Int, scale int) *BigDecimal {
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	return &BigDecimal{unscaled, scale}
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// Sign return -1, 0 or +1 depending on the sign of d.
+func (d *BigDecimal) Sign() int {
+	return d.Unscaled.Sign()
+}
+
+// Cmp compare d and x exactly, return -1 if d < x, 0 if d == x, +1 if d > x.
+func (d *BigDecimal) Cmp(x *BigDecimal) int {
+	a, b, _ := alignDecimals(d, x)
+	return a.Cmp(b)
+}
+
+// Digits return the count of digits in d, ignoring leading zeros and
+// trailing zeros after the decimal point. Zero has one digit.
+func (d *BigDecimal) Digits() int {
+	n := d.IntDigits() + d.DecimalPlaces()
+	if n == 0 {
+		return 1
+	}
+	return n
+}
+
+// IntDigits return the count of digits before the decimal point, it is zero
+// if the absolute value of d is smaller than one.
+func (d *BigDecimal) IntDigits() int {
+	if d.Unscaled.Sign() == 0 {
+		return 0
+	}
+	n := len(new(big.Int).Abs(d.Unscaled).String()) - d.Scale
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// DecimalPlaces return the count of digits after the decimal point,
+// ignoring trailing zeros.
+func (d *BigDecimal) DecimalPlaces() int {
+	places := d.Scale
+	if d.Unscaled.Sign() == 0 {
+		return 0
+	}
+	v := new(big.Int).Set(d.Unscaled)
+	r := new(big.Int)
+	for places > 0 {
+		v.QuoRem(v, bigTen, r)
+		if r.Sign() != 0 {
+			break
+		}
+		places--
+	}
+	return places
+}
+
+// Round return a new decimal rounded to the specified scale.
+func (d *BigDecimal) Round(scale int, mode RoundingMode) *BigDecimal {
+	if scale < 0 {
+		scale = 0
+	}
+	if d.Scale <= scale {
+		v := new(big.Int).Mul(d.Unscaled, pow10(scale-d.Scale))
+		return &BigDecimal{v, scale}
+	}
+
+	divisor := pow10(d.Scale - scale)
+	q, r := new(big.Int).QuoRem(d.Unscaled, divisor, new(big.Int))
+	if r.Sign() == 0 {
+		return &BigDecimal{q, scale}
+	}
+
+	// compare 2*|r| with divisor to find out the position of remainder
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1)
+	halfCmp := half.Cmp(divisor)
+	negative := d.Unscaled.Sign() < 0
+
+	awayFromZero := false
+	switch mode {
+	case RoundHalfUp:
+		awayFromZero = halfCmp >= 0
+	case RoundHalfDown:
+		awayFromZero = halfCmp > 0
+	case RoundHalfEven:
+		awayFromZero = halfCmp > 0 || (halfCmp == 0 && q.Bit(0) == 1)
+	case RoundUp:
+		awayFromZero = true
+	case RoundDown:
+		awayFromZero = false
+	case RoundCeiling:
+		awayFromZero = !negative
+	case RoundFloor:
+		awayFromZero = negative
+	}
+	if awayFromZero {
+		if negative {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return &BigDecimal{q, scale}
+}
+
+// Add return a new decimal with value d + x.
+func (d *BigDecimal) Add(x *BigDecimal) *BigDecimal {
+	a, b, scale := alignDecimals(d, x)
+	return &BigDecimal{a.Add(a, b), scale}
+}
+
+// Sub return a new decimal with value d - x.
+func (d *BigDecimal) Sub(x *BigDecimal) *BigDecimal {
+	a, b, scale := alignDecimals(d, x)
+	return &BigDecimal{a.Sub(a, b), scale}
+}
+
+// alignDecimals return copies of unscaled values of d and x at the same scale.
+func alignDecimals(d, x *BigDecimal) (*big.Int, *big.Int, int) {
+	a := new(big.Int).Set(d.Unscaled)
+	b := new(big.Int).Set(x.Unscaled)
+	if d.Scale < x.Scale {
+		a.Mul(a, pow10(x.Scale-d.Scale))
+		return a, b, x.Scale
+	}
+	if d.Scale > x.Scale {
+		b.Mul(b, pow10(d.Scale-x.Scale))
+	}
+	return a, b, d.Scale
+}
+
+// Rat return d as a big.Rat.
+func (d *BigDecimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.Unscaled, pow10(d.Scale))
+}
+
+// String return the plain decimal representation of d.
+func (d *BigDecimal) String() string {
+	s := new(big.Int).Abs(d.Unscaled).String()
+	if d.Scale > 0 {
+		if len(s) <= d.Scale {
+			s = strings.Repeat("0", d.Scale-len(s)+1) + s
+		}
+		s = s[:len(s)-d.Scale] + "." + s[len(s)-d.Scale:]
+	}
+	if d.Unscaled.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
@@ -0,0 +1,131 @@
+package filter
+
+import "testing"
+
+func TestParseBigDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"0", "0", true},
+		{"-12.345", "-12.345", true},
+		{"+1.50", "1.50", true},
+		{".5", "0.5", true},
+		{"1.5e3", "1500", true},
+		{"1.5E-3", "0.0015", true},
+		{"1e1000", "1" + zeros(1000), true},
+		{"1e1001", "", false},
+		{"1e-1001", "", false},
+		{"1e300000000", "", false},
+		{"1e-300000000", "", false},
+		{"0." + zeros(1000) + "1", "", false},
+		{"", "", false},
+		{".", "", false},
+		{"1.2.3", "", false},
+		{"0x10", "", false},
+		{"1e", "", false},
+	}
+	for _, tt := range tests {
+		d, err := ParseBigDecimal(tt.in)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseBigDecimal(%.20q) error = %v, want ok %v", tt.in, err, tt.ok)
+			continue
+		}
+		if tt.ok && d.String() != tt.want {
+			t.Errorf("ParseBigDecimal(%.20q) = %.20s, want %.20s", tt.in, d.String(), tt.want)
+		}
+	}
+}
+
+func zeros(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = '0'
+	}
+	return string(b)
+}
+
+func TestBigDecimalRound(t *testing.T) {
+	tests := []struct {
+		in    string
+		scale int
+		mode  RoundingMode
+		want  string
+	}{
+		{"2.345", 2, RoundHalfUp, "2.35"},
+		{"-2.345", 2, RoundHalfUp, "-2.35"},
+		{"2.345", 2, RoundHalfDown, "2.34"},
+		{"2.345", 2, RoundHalfEven, "2.34"},
+		{"2.355", 2, RoundHalfEven, "2.36"},
+		{"2.341", 2, RoundUp, "2.35"},
+		{"2.349", 2, RoundDown, "2.34"},
+		{"-2.341", 2, RoundCeiling, "-2.34"},
+		{"-2.341", 2, RoundFloor, "-2.35"},
+		{"2.3", 3, RoundHalfUp, "2.300"},
+	}
+	for _, tt := range tests {
+		d, _ := ParseBigDecimal(tt.in)
+		if got := d.Round(tt.scale, tt.mode).String(); got != tt.want {
+			t.Errorf("%s.Round(%d, %d) = %s, want %s", tt.in, tt.scale, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalFilter(t *testing.T) {
+	tests := []struct {
+		f    *DecimalFilter
+		in   interface{}
+		want string
+		word string
+	}{
+		{Decimal(), "12.50", "12.50", ""},
+		{Decimal().Max("100"), "1e300000000", "", "NotDecimal"},
+		{Decimal().Max("100"), "100.01", "", "TooLarge"},
+		{Decimal().Min("0.1"), "0.0999", "", "TooSmall"},
+		{Decimal().Precision(5, 2), "123.45", "123.45", ""},
+		{Decimal().Precision(5, 2), "1234.5", "", "TooManyDigits"},
+		{Decimal().Scale(2), "1.234", "", "TooManyDecimalPlaces"},
+		{Decimal().Round(1, RoundHalfEven), "0.25", "0.2", ""},
+		{Decimal(), "abc", "", "NotDecimal"},
+	}
+	for _, tt := range tests {
+		got, err := tt.f.Run("p", tt.in)
+		if errWord(err) != tt.word {
+			t.Errorf("Run(%v) error = %v, want %q", tt.in, err, tt.word)
+			continue
+		}
+		if err == nil && got.(*BigDecimal).String() != tt.want {
+			t.Errorf("Run(%v) = %v, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalRangeFilter(t *testing.T) {
+	tests := []struct {
+		f    *DecimalRangeFilter
+		in   string
+		want string
+		word string
+	}{
+		{DecimalRange(), "[1.5,20)", "1.5,20", ""},
+		{DecimalRange().LeftDefault("0"), "[,20]", "0,20", ""},
+		{DecimalRange().LeftDefault("bad"), "[1,20]", "", "InvalidValidator"},
+		{DecimalRange().RightDefault("1e99999"), "[1,20]", "", "InvalidValidator"},
+		{DecimalRange(), "[20,1]", "", "NotDecimalRange"},
+		{DecimalRange().MaxDistance("10"), "[1,20]", "", "TooFar"},
+	}
+	for _, tt := range tests {
+		got, err := tt.f.Run("p", tt.in)
+		if errWord(err) != tt.word {
+			t.Errorf("Run(%q) error = %v, want %q", tt.in, err, tt.word)
+			continue
+		}
+		if err == nil {
+			r := got.(*BigDecimalRange)
+			if s := r.Left.String() + "," + r.Right.String(); s != tt.want {
+				t.Errorf("Run(%q) = %s, want %s", tt.in, s, tt.want)
+			}
+		}
+	}
+}
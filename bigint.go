@@ -0,0 +1,278 @@
+package filter
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+type BigIntFilter struct {
+	base       int
+	strict     strictMode
+	validators []BigIntValidator
+	allowVals  allowList
+}
+
+type BigIntValidator func(paramName string, paramValue *big.Int) *Error
+
+var errInvalidBigInt = errors.New("invalid big int")
+
+// BigInt return a arbitrary-precision int filter.
+func BigInt() *BigIntFilter {
+	f := new(BigIntFilter)
+	f.base = 10
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *BigIntFilter) Allow(vals ...string) *BigIntFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *BigIntFilter) AllowSpecial(special Special, vals ...string) *BigIntFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *BigIntFilter) AllowSpecialFold(special Special, vals ...string) *BigIntFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Base set the base of int.
+// BigIntFilter interprets a string s in the given base (2 to 62) and returns
+// the corresponding value i. If base == 0, the base is implied by the
+// string's prefix: base 16 for "0x", base 8 for "0", and base 10 otherwise.
+func (f *BigIntFilter) Base(base int) *BigIntFilter {
+	f.base = base
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *BigIntFilter) Strict() *BigIntFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *BigIntFilter) Lenient() *BigIntFilter {
+	f.strict = strictOff
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *BigIntFilter) AddValidator(validator BigIntValidator) *BigIntFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// Min valid param value should not be smaller than the specified decimal value.
+func (f *BigIntFilter) Min(val string) *BigIntFilter {
+	i, parseErr := parseBigInt(val, 10)
+	f.AddValidator(func(paramName string, paramValue *big.Int) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Cmp(i) < 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// Max valid param value should not be larger than the specified decimal value.
+func (f *BigIntFilter) Max(val string) *BigIntFilter {
+	i, parseErr := parseBigInt(val, 10)
+	f.AddValidator(func(paramName string, paramValue *big.Int) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Cmp(i) > 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// LargerThan valid param value should be larger than the specified decimal value.
+func (f *BigIntFilter) LargerThan(val string) *BigIntFilter {
+	i, parseErr := parseBigInt(val, 10)
+	f.AddValidator(func(paramName string, paramValue *big.Int) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Cmp(i) <= 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// SmallerThan valid param value should be smaller than the specified decimal value.
+func (f *BigIntFilter) SmallerThan(val string) *BigIntFilter {
+	i, parseErr := parseBigInt(val, 10)
+	f.AddValidator(func(paramName string, paramValue *big.Int) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Cmp(i) >= 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// Equal valid param value should be equal to the specified decimal value.
+func (f *BigIntFilter) Equal(val string) *BigIntFilter {
+	i, parseErr := parseBigInt(val, 10)
+	f.AddValidator(func(paramName string, paramValue *big.Int) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		c := paramValue.Cmp(i)
+		if c < 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooSmall")
+		}
+		if c > 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// Between valid param value should in the specified range.
+func (f *BigIntFilter) Between(min, max string) *BigIntFilter {
+	minVal, minErr := parseBigInt(min, 10)
+	maxVal, maxErr := parseBigInt(max, 10)
+	f.AddValidator(func(paramName string, paramValue *big.Int) *Error {
+		if minErr != nil || maxErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Cmp(minVal) < 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooSmall")
+		}
+		if paramValue.Cmp(maxVal) > 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// In valid param value should in the specified set.
+func (f *BigIntFilter) In(set []string) *BigIntFilter {
+	intSet, parseErr := parseBigInts(set, 10)
+	f.AddValidator(func(paramName string, paramValue *big.Int) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		for _, v := range intSet {
+			if v.Cmp(paramValue) == 0 {
+				return nil
+			}
+		}
+		return NewError(ErrorInvalidParam, paramName, "NotInSet")
+	})
+	return f
+}
+
+// MaxBitLen valid whether the bit length of absolute param value is not
+// larger than the specified value, eg: 128 for a 128-bit unsigned id.
+func (f *BigIntFilter) MaxBitLen(bits int) *BigIntFilter {
+	f.AddValidator(func(paramName string, paramValue *big.Int) *Error {
+		if paramValue.BitLen() > bits {
+			return NewError(ErrorInvalidParam, paramName, "TooManyBits")
+		}
+		return nil
+	})
+	return f
+}
+
+// Run make the filter running.
+func (f *BigIntFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var intVal *big.Int
+	switch val := paramValue.(type) {
+	case string:
+		raw := val
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.check(raw, f.base, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		v, err := parseBigInt(val, f.base)
+		if err != nil {
+			goto parse_error
+		}
+		intVal = v
+	case *big.Int:
+		intVal = val
+	case big.Int:
+		intVal = &val
+	case int:
+		intVal = big.NewInt(int64(val))
+	case int64:
+		intVal = big.NewInt(val)
+	case uint64:
+		intVal = new(big.Int).SetUint64(val)
+	default:
+		v, word, ok := coerceBigInt(val)
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		intVal = v
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, intVal); err != nil {
+			return nil, err
+		}
+	}
+
+	return intVal, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotBigInt")
+}
+
+// parseBigInt parse a big int string in the specified base.
+func parseBigInt(val string, base int) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(val, base)
+	if !ok {
+		return nil, errInvalidBigInt
+	}
+	return i, nil
+}
+
+// parseBigInts parse a list of big int strings in the specified base.
+func parseBigInts(vals []string, base int) ([]*big.Int, error) {
+	intVals := make([]*big.Int, 0, len(vals))
+	for _, val := range vals {
+		i, err := parseBigInt(val, base)
+		if err != nil {
+			return nil, err
+		}
+		intVals = append(intVals, i)
+	}
+	return intVals, nil
+}
@@ -0,0 +1,33 @@
+package filter
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBigIntFilter(t *testing.T) {
+	testRun(t, []runCase{
+		{BigInt(), "123456789012345678901234567890", "123456789012345678901234567890", ""},
+		{BigInt(), "-42", "-42", ""},
+		{BigInt().Base(16), "ff", "255", ""},
+		{BigInt(), "1.5", "", "NotBigInt"},
+		{BigInt(), int64(7), "7", ""},
+		{BigInt(), new(big.Int).Lsh(big.NewInt(1), 100), "1267650600228229401496703205376", ""},
+		{BigInt().Max("1000"), "1001", "", "TooLarge"},
+		{BigInt().Min("-1000"), "-1001", "", "TooSmall"},
+		{BigInt().MaxBitLen(64), "18446744073709551616", "", "TooManyBits"},
+		{BigInt().In([]string{"1", "2"}), "3", "", "NotInSet"},
+		{BigInt().Max("bad"), "1", "", "InvalidValidator"},
+	})
+}
+
+func TestBigIntSetFilter(t *testing.T) {
+	testRun(t, []runCase{
+		{BigIntSet(), "1,2,99999999999999999999", "[1 2 99999999999999999999]", ""},
+		{BigIntSet(), "", "[]", ""},
+		{BigIntSet(), "1,x", "", "NotBigIntSet"},
+		{BigIntSet().MaxCount(1), "1,2", "", "TooMany"},
+		{BigIntSet().ItemMax("10"), "1,11", "", "ItemTooLarge"},
+		{BigIntSet().ItemMaxBitLen(8), "255,256", "", "ItemTooManyBits"},
+	})
+}
@@ -0,0 +1,148 @@
+package filter
+
+import (
+	"strings"
+)
+
+type BoolFilter struct {
+	trueVals   []string
+	falseVals  []string
+	strictCase bool
+	validators []BoolValidator
+	allowVals  allowList
+}
+
+type BoolValidator func(paramName string, paramValue bool) *Error
+
+// default vocabularies of bool filter
+var (
+	DefaultBoolTrueValues  = []string{"true", "1", "yes", "on", "y", "t", "是"}
+	DefaultBoolFalseValues = []string{"false", "0", "no", "off", "n", "f", "否"}
+)
+
+// Bool return a bool filter.
+func Bool() *BoolFilter {
+	f := new(BoolFilter)
+	f.trueVals = DefaultBoolTrueValues
+	f.falseVals = DefaultBoolFalseValues
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *BoolFilter) Allow(vals ...string) *BoolFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *BoolFilter) AllowSpecial(special Special, vals ...string) *BoolFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *BoolFilter) AllowSpecialFold(special Special, vals ...string) *BoolFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// TrueValues set the strings which are treated as true, eg: "yes", "on".
+func (f *BoolFilter) TrueValues(vals ...string) *BoolFilter {
+	f.trueVals = vals
+	return f
+}
+
+// FalseValues set the strings which are treated as false, eg: "no", "off".
+func (f *BoolFilter) FalseValues(vals ...string) *BoolFilter {
+	f.falseVals = vals
+	return f
+}
+
+// StrictCase match the true and false values case sensitively.
+func (f *BoolFilter) StrictCase() *BoolFilter {
+	f.strictCase = true
+	return f
+}
+
+// IgnoreCase match the true and false values case insensitively, this is the
+// default behavior.
+func (f *BoolFilter) IgnoreCase() *BoolFilter {
+	f.strictCase = false
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *BoolFilter) AddValidator(validator BoolValidator) *BoolFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// Equal valid param value should be equal to the specified value.
+func (f *BoolFilter) Equal(val bool) *BoolFilter {
+	f.AddValidator(func(paramName string, paramValue bool) *Error {
+		if paramValue != val {
+			if val {
+				return NewError(ErrorInvalidParam, paramName, "NotTrue")
+			}
+			return NewError(ErrorInvalidParam, paramName, "NotFalse")
+		}
+		return nil
+	})
+	return f
+}
+
+// match check whether val is in the vocabulary.
+func (f *BoolFilter) match(vocabulary []string, val string) bool {
+	for _, v := range vocabulary {
+		if v == val || (!f.strictCase && strings.EqualFold(v, val)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run make the filter running.
+func (f *BoolFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var boolVal bool
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if f.match(f.trueVals, val) {
+			boolVal = true
+		} else if f.match(f.falseVals, val) {
+			boolVal = false
+		} else {
+			goto parse_error
+		}
+	case bool:
+		boolVal = val
+	default:
+		// numbers must be exactly 0 or 1
+		v, word, ok := coerceInt64(val, 0, 1)
+		if !ok || word != "" {
+			goto parse_error
+		}
+		boolVal = v == 1
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, boolVal); err != nil {
+			return nil, err
+		}
+	}
+
+	return boolVal, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotBool")
+}
@@ -0,0 +1,27 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBoolFilter(t *testing.T) {
+	testRun(t, []runCase{
+		{Bool(), "true", "true", ""},
+		{Bool(), "YES", "true", ""},
+		{Bool(), "是", "true", ""},
+		{Bool(), "off", "false", ""},
+		{Bool(), "0", "false", ""},
+		{Bool(), "maybe", "", "NotBool"},
+		{Bool().StrictCase(), "YES", "", "NotBool"},
+		{Bool().TrueValues("ok").FalseValues("ko"), "ok", "true", ""},
+		{Bool().TrueValues("ok").FalseValues("ko"), "yes", "", "NotBool"},
+		{Bool(), 1, "true", ""},
+		{Bool(), 2, "", "NotBool"},
+		{Bool(), float64(0), "false", ""},
+		{Bool(), json.Number("1"), "true", ""},
+		{Bool(), uint8(0), "false", ""},
+		{Bool(), 0.5, "", "NotBool"},
+		{Bool().Equal(true), "no", "", "NotTrue"},
+	})
+}
@@ -0,0 +1,289 @@
+package filter
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Calendar is a business calendar with weekends, holidays, make-up working
+// days (eg: 调休 in China) and working hours. Dates are evaluated in the
+// location of calendar, which is the filter's location by default.
+type Calendar struct {
+	loc       *time.Location
+	weekends  map[time.Weekday]bool
+	holidays  map[string]bool
+	workdays  map[string]bool
+	workStart time.Duration
+	workEnd   time.Duration
+}
+
+// CalendarData is the JSON format of calendar files, eg:
+// {"holidays": ["2024-10-01", "2024-10-02"], "workdays": ["2024-09-29"]}
+type CalendarData struct {
+	Holidays []string `json:"holidays"`
+	Workdays []string `json:"workdays"`
+}
+
+var errInvalidCalendar = errors.New("invalid calendar")
+
+const calendarDateLayout = "2006-01-02"
+
+// NewCalendar return a calendar with saturday and sunday as weekends and
+// working hours from 09:00 to 18:00.
+func NewCalendar() *Calendar {
+	c := new(Calendar)
+	c.weekends = map[time.Weekday]bool{time.Saturday: true, time.Sunday: true}
+	c.holidays = make(map[string]bool)
+	c.workdays = make(map[string]bool)
+	c.workStart = 9 * time.Hour
+	c.workEnd = 18 * time.Hour
+	return c
+}
+
+// Location set the location to evaluate dates in.
+func (c *Calendar) Location(loc *time.Location) *Calendar {
+	c.loc = loc
+	return c
+}
+
+// Weekends set the weekend days.
+func (c *Calendar) Weekends(days ...time.Weekday) *Calendar {
+	c.weekends = make(map[time.Weekday]bool)
+	for _, day := range days {
+		c.weekends[day] = true
+	}
+	return c
+}
+
+// Holidays add holidays in "2006-01-02" format, invalid dates are ignored.
+func (c *Calendar) Holidays(dates ...string) *Calendar {
+	for _, date := range dates {
+		if _, err := time.Parse(calendarDateLayout, date); err == nil {
+			c.holidays[date] = true
+		}
+	}
+	return c
+}
+
+// Workdays add make-up working days in "2006-01-02" format, they are working
+// days even on weekends. Invalid dates are ignored.
+func (c *Calendar) Workdays(dates ...string) *Calendar {
+	for _, date := range dates {
+		if _, err := time.Parse(calendarDateLayout, date); err == nil {
+			c.workdays[date] = true
+		}
+	}
+	return c
+}
+
+// WorkingHours set the working hours of day, eg: WorkingHours("09:00", "18:00").
+// Both ends are inclusive.
+func (c *Calendar) WorkingHours(start, end string) *Calendar {
+	s, err1 := time.Parse("15:04", start)
+	e, err2 := time.Parse("15:04", end)
+	if err1 == nil && err2 == nil {
+		c.workStart = time.Duration(s.Hour())*time.Hour + time.Duration(s.Minute())*time.Minute
+		c.workEnd = time.Duration(e.Hour())*time.Hour + time.Duration(e.Minute())*time.Minute
+	}
+	return c
+}
+
+// LoadJSON load holidays and make-up working days from a JSON file in
+// CalendarData format.
+func (c *Calendar) LoadJSON(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	data := new(CalendarData)
+	if err := json.NewDecoder(file).Decode(data); err != nil {
+		return err
+	}
+	for _, date := range append(data.Holidays, data.Workdays...) {
+		if _, err := time.Parse(calendarDateLayout, date); err != nil {
+			return errInvalidCalendar
+		}
+	}
+	c.Holidays(data.Holidays...)
+	c.Workdays(data.Workdays...)
+	return nil
+}
+
+// LoadICS load holidays from an iCalendar file. Each all-day event is a
+// holiday, events with "班" or "workday" in summary are make-up working days.
+func (c *Calendar) LoadICS(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return c.readICS(file)
+}
+
+// readICS parse VEVENT blocks of an iCalendar stream.
+func (c *Calendar) readICS(r io.Reader) error {
+	// unfold continuation lines first
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	var inEvent bool
+	var start, end, summary string
+	for _, line := range lines {
+		i := strings.IndexByte(line, ':')
+		if i < 0 {
+			continue
+		}
+		name, value := strings.ToUpper(line[:i]), line[i+1:]
+		if j := strings.IndexByte(name, ';'); j >= 0 {
+			name = name[:j]
+		}
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			inEvent = true
+			start, end, summary = "", "", ""
+		case name == "END" && value == "VEVENT":
+			inEvent = false
+			if err := c.addICSEvent(start, end, summary); err != nil {
+				return err
+			}
+		case inEvent && name == "DTSTART":
+			start = value
+		case inEvent && name == "DTEND":
+			end = value
+		case inEvent && name == "SUMMARY":
+			summary = value
+		}
+	}
+	return nil
+}
+
+// addICSEvent mark the days of event, end date is exclusive.
+func (c *Calendar) addICSEvent(start, end, summary string) error {
+	if len(start) < 8 {
+		return errInvalidCalendar
+	}
+	startDay, err := time.Parse("20060102", start[:8])
+	if err != nil {
+		return errInvalidCalendar
+	}
+	endDay := startDay.AddDate(0, 0, 1)
+	if len(end) >= 8 {
+		if endDay, err = time.Parse("20060102", end[:8]); err != nil {
+			return errInvalidCalendar
+		}
+	}
+
+	isWorkday := strings.Contains(summary, "班") || strings.Contains(strings.ToLower(summary), "workday")
+	for day := startDay; day.Before(endDay); day = day.AddDate(0, 0, 1) {
+		date := day.Format(calendarDateLayout)
+		if isWorkday {
+			c.workdays[date] = true
+		} else {
+			c.holidays[date] = true
+		}
+	}
+	return nil
+}
+
+// location return the location of calendar.
+func (c *Calendar) location() *time.Location {
+	if c.loc != nil {
+		return c.loc
+	}
+	return timeLoc
+}
+
+// IsHoliday check whether t is on a holiday.
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	return c.holidays[t.In(c.location()).Format(calendarDateLayout)]
+}
+
+// IsWorkday check whether t is on a working day, make-up working days win
+// over weekends and holidays win over weekdays.
+func (c *Calendar) IsWorkday(t time.Time) bool {
+	t = t.In(c.location())
+	date := t.Format(calendarDateLayout)
+	if c.workdays[date] {
+		return true
+	}
+	if c.holidays[date] {
+		return false
+	}
+	return !c.weekends[t.Weekday()]
+}
+
+// InWorkingHours check whether t is in working hours of a working day.
+func (c *Calendar) InWorkingHours(t time.Time) bool {
+	if !c.IsWorkday(t) {
+		return false
+	}
+	t = t.In(c.location())
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+	return offset >= c.workStart && offset <= c.workEnd
+}
+
+// BusinessDays return the number of working days from the date of start to
+// the date of end, both inclusive. Whole weeks are counted arithmetically so
+// the cost depends on the number of holidays and make-up working days, not
+// on the length of the period.
+func (c *Calendar) BusinessDays(start, end time.Time) int {
+	first, last := civilDate(start.In(c.location())), civilDate(end.In(c.location()))
+	if first.After(last) {
+		return 0
+	}
+
+	days := (last.Unix()-first.Unix())/(24*60*60) + 1
+	workdaysPerWeek := 0
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if !c.weekends[day] {
+			workdaysPerWeek++
+		}
+	}
+	count := days / 7 * int64(workdaysPerWeek)
+	weekday := first.Weekday()
+	for i := int64(0); i < days%7; i++ {
+		if !c.weekends[(weekday+time.Weekday(i))%7] {
+			count++
+		}
+	}
+
+	for date := range c.holidays {
+		t, err := time.Parse(calendarDateLayout, date)
+		if err == nil && !c.workdays[date] && !c.weekends[t.Weekday()] && !t.Before(first) && !t.After(last) {
+			count--
+		}
+	}
+	for date := range c.workdays {
+		t, err := time.Parse(calendarDateLayout, date)
+		if err == nil && c.weekends[t.Weekday()] && !t.Before(first) && !t.After(last) {
+			count++
+		}
+	}
+	return int(count)
+}
+
+// civilDate return the date of t at midnight in UTC, which has no DST
+// changes so days are always 24 hours.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
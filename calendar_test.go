@@ -0,0 +1,121 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func testCalendar() *Calendar {
+	// National Day 2024 in China with make-up working days
+	return NewCalendar().
+		Holidays("2024-10-01", "2024-10-02", "2024-10-03", "2024-10-04", "2024-10-05", "2024-10-06", "2024-10-07").
+		Workdays("2024-09-29", "2024-10-12")
+}
+
+func TestCalendar(t *testing.T) {
+	cal := testCalendar().WorkingHours("09:00", "18:00")
+	date := func(s string) time.Time {
+		tm, _ := time.ParseInLocation(LayoutDateTime, s, timeLoc)
+		return tm
+	}
+	tests := []struct {
+		t                         string
+		holiday, workday, working bool
+	}{
+		{"2024-09-27 10:00:00", false, true, true},
+		{"2024-09-28 10:00:00", false, false, false},
+		{"2024-09-29 10:00:00", false, true, true},
+		{"2024-10-01 10:00:00", true, false, false},
+		{"2024-10-08 08:59:59", false, true, false},
+		{"2024-10-08 18:00:00", false, true, true},
+	}
+	for _, tt := range tests {
+		tm := date(tt.t)
+		if cal.IsHoliday(tm) != tt.holiday || cal.IsWorkday(tm) != tt.workday || cal.InWorkingHours(tm) != tt.working {
+			t.Errorf("%s: holiday %v workday %v working %v", tt.t, cal.IsHoliday(tm), cal.IsWorkday(tm), cal.InWorkingHours(tm))
+		}
+	}
+}
+
+func TestBusinessDays(t *testing.T) {
+	cal := testCalendar()
+	date := func(s string) time.Time {
+		tm, _ := time.ParseInLocation(LayoutDate, s, timeLoc)
+		return tm
+	}
+	tests := []struct {
+		start, end string
+		want       int
+	}{
+		{"2024-09-30", "2024-09-30", 1},
+		{"2024-09-28", "2024-09-28", 0},
+		{"2024-09-23", "2024-09-29", 6},
+		{"2024-09-28", "2024-10-13", 7},
+		{"2024-10-01", "2024-10-07", 0},
+		{"2024-01-01", "2024-12-31", 262 - 5 + 2},
+		{"2024-10-13", "2024-10-01", 0},
+	}
+	for _, tt := range tests {
+		if got := cal.BusinessDays(date(tt.start), date(tt.end)); got != tt.want {
+			t.Errorf("BusinessDays(%s, %s) = %d, want %d", tt.start, tt.end, got, tt.want)
+		}
+	}
+
+	// compare with counting day by day
+	start := date("2024-09-01")
+	for n := 0; n < 60; n++ {
+		end := start.AddDate(0, 0, n)
+		want := 0
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			if cal.IsWorkday(day) {
+				want++
+			}
+		}
+		if got := cal.BusinessDays(start, end); got != want {
+			t.Errorf("BusinessDays(+%d days) = %d, want %d", n, got, want)
+		}
+	}
+
+	// long periods do not iterate day by day
+	far := time.Date(9999, 12, 31, 0, 0, 0, 0, timeLoc)
+	if got := cal.BusinessDays(time.Date(1, 1, 1, 0, 0, 0, 0, timeLoc), far); got <= 0 {
+		t.Errorf("BusinessDays(1-01-01, 9999-12-31) = %d", got)
+	}
+}
+
+func TestCalendarICS(t *testing.T) {
+	ics := `BEGIN:VCALENDAR
+BEGIN:VEVENT
+DTSTART;VALUE=DATE:20241001
+DTEND;VALUE=DATE:20241003
+SUMMARY:国庆节
+END:VEVENT
+BEGIN:VEVENT
+DTSTART;VALUE=DATE:20240929
+SUMMARY:国庆节 补班
+END:VEVENT
+END:VCALENDAR
+`
+	cal := NewCalendar()
+	if err := cal.readICS(strings.NewReader(ics)); err != nil {
+		t.Fatal(err)
+	}
+	date := func(s string) time.Time {
+		tm, _ := time.ParseInLocation(LayoutDate, s, timeLoc)
+		return tm
+	}
+	if !cal.IsHoliday(date("2024-10-02")) || cal.IsHoliday(date("2024-10-03")) || !cal.IsWorkday(date("2024-09-29")) {
+		t.Error("readICS mismatch")
+	}
+}
+
+func TestTimeRangeBusinessDays(t *testing.T) {
+	cal := testCalendar()
+	testRun(t, []runCase{
+		{TimeRange().MinBusinessDays(cal, 5), "2024-09-28~2024-10-08", "", "TooNear"},
+		{TimeRange().MaxBusinessDays(cal, 5), "2024-09-28~2024-10-13", "", "TooFar"},
+		{TimeRange().MaxBusinessDays(cal, 7), "2024-09-28~2024-10-13", "[2024-09-28 00:00:00,2024-10-13 00:00:00]", ""},
+		{TimeRange().Workday(cal), "2024-10-01~2024-10-08", "", "LeftNotWorkday"},
+	})
+}
@@ -7,7 +7,7 @@ import (
 
 type CIDRFilter struct {
 	validators []CIDRValidator
-	allowVals  []string
+	allowVals  allowList
 	toString   bool
 }
 
@@ -35,9 +35,24 @@ func (f *CIDRFilter) AddValidator(validator CIDRValidator) *CIDRFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *CIDRFilter) Allow(vals ...string) *CIDRFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *CIDRFilter) AllowSpecial(special Special, vals ...string) *CIDRFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *CIDRFilter) AllowSpecialFold(special Special, vals ...string) *CIDRFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -81,10 +96,8 @@ func (f *CIDRFilter) Run(paramName string, paramValue interface{}) (interface{},
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		ip, net, err := net.ParseCIDR(val)
 		if err != nil {
@@ -0,0 +1,22 @@
+package filter
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	errInvalidClamp      = errors.New("invalid clamp range")
+	errClampedRangeEmpty = errors.New("range is empty after clamping")
+)
+
+// ClampHook is called when a param value is clamped, original and adjusted
+// are values of the output type of the filter.
+type ClampHook func(paramName string, original, adjusted interface{})
+
+// isRangeErr check whether err is a strconv range error, the parsed value is
+// saturated to the limit of the type then and can be clamped.
+func isRangeErr(err error) bool {
+	numErr, ok := err.(*strconv.NumError)
+	return ok && numErr.Err == strconv.ErrRange
+}
@@ -0,0 +1,42 @@
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-apibox/types"
+)
+
+func TestClampNumbers(t *testing.T) {
+	testRun(t, []runCase{
+		{Int64().Clamp(0, 100), "100000", "100", ""},
+		{Int64().Clamp(0, 100), "100000000000000000000", "100", ""},
+		{Int64().Clamp(0, 100), "-100000000000000000000", "0", ""},
+		{Int64(), "100000000000000000000", "", "NotInt64"},
+		{Int32().Clamp(0, 100), "5000000000", "100", ""},
+		{Int32(), "-5000000000", "", "NotInt32"},
+		{Uint32().Clamp(0, 10), "99999999999", "10", ""},
+		{Timestamp64().Clamp(0, 100), "99999999999999999999", "100", ""},
+		{Int().Clamp(100, 0), "5", "", "InvalidValidator"},
+		{Decimal().Clamp("1", "0"), "5", "", "InvalidValidator"},
+		{Float64().Clamp(0, 1), "NaN", "", "NumberNotFinite"},
+		{Float64().Clamp(0, 1), math.NaN(), "", "NumberNotFinite"},
+		{Float32().Clamp(0, 1), "2", "1", ""},
+	})
+}
+
+func TestClampRanges(t *testing.T) {
+	testRun(t, []runCase{
+		{Timestamp64Range().Clamp(0, 100), "[50,200)", "[50,100]", ""},
+		{Timestamp64Range().Clamp(0, 100), "(200,300)", "[100,100]", ""},
+		{Timestamp64Range().Clamp(0, 100), "(100,200)", "", "RangeEmptyAfterClamp"},
+		{Timestamp64Range().Clamp(100, 0), "[1,2]", "", "InvalidValidator"},
+		{Int64Range().Clamp(0, 100), types.Int64Range{Left: 100, Right: 200}, "", "RangeEmptyAfterClamp"},
+		{Int64Range().Clamp(0, 100), types.Int64Range{Left: 150, Right: 200, LeftClosed: true}, "[100,100]", ""},
+		{Uint64Range().Clamp(10, 20), types.Uint64Range{Left: 0, Right: 10, LeftClosed: true}, "", "RangeEmptyAfterClamp"},
+		{DecimalRange().Clamp("0", "100"), "(100,200)", "", "RangeEmptyAfterClamp"},
+		{DecimalRange().Clamp("0", "100"), "[-5,5)", "[0,5)", ""},
+		{TimeRange().Clamp("2024-01-01", "2024-02-01"), "(2024-02-01,2024-03-01)", "", "RangeEmptyAfterClamp"},
+		{TimeRange().Clamp("2024-02-01", "2024-01-01"), "[2024-01-05,2024-01-06]", "", "InvalidValidator"},
+	})
+}
@@ -0,0 +1,40 @@
+package filter
+
+import (
+	"time"
+)
+
+// Clock provides the current time to time-based filters, it can be replaced
+// with SetClock to get deterministic results in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+// Now return the current system time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+var clock Clock = systemClock{}
+
+// SetClock set the clock used by time-based filters, nil restores the
+// system clock.
+func SetClock(c Clock) {
+	if c == nil {
+		c = systemClock{}
+	}
+	clock = c
+}
+
+// now return the current time of clock.
+func now() time.Time {
+	return clock.Now()
+}
+
+// NowTimestamp return the current unix timestamp of clock, it can be used as
+// a default value provider of TimestampRangeFilter.
+func NowTimestamp() uint32 {
+	return uint32(now().Unix())
+}
@@ -0,0 +1,44 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// setTestClock set a fixed clock for the test and restore the system clock
+// on cleanup.
+func setTestClock(t *testing.T, tm time.Time) {
+	SetClock(fixedClock(tm))
+	t.Cleanup(func() { SetClock(nil) })
+}
+
+func TestClock(t *testing.T) {
+	setTestClock(t, time.Date(2024, 5, 16, 10, 30, 0, 0, timeLoc))
+
+	if got := NowTimestamp(); got != uint32(time.Date(2024, 5, 16, 10, 30, 0, 0, timeLoc).Unix()) {
+		t.Errorf("NowTimestamp() = %d", got)
+	}
+	testRun(t, []runCase{
+		{Time().StartFrom("today-1d"), "2024-05-15", "2024-05-15 00:00:00", ""},
+		{Time().StartFrom("today-1d"), "2024-05-14", "", "TooEarly"},
+		{Time().Before("now"), "2024-05-16", "2024-05-16 00:00:00", ""},
+		{Time().Before("today"), "2024-05-16", "", "TooLate"},
+		{TimeRange().LeftDefault("startOfMonth").RightDefault("today"), "~", "[2024-05-01 00:00:00,2024-05-16 00:00:00]", ""},
+		{TimeRange().RightDefault("now"), "2024-05-01~", "[2024-05-01 00:00:00,2024-05-16 10:30:00]", ""},
+		{TimeRange().LeftDefaultFunc(func() time.Time { return now().AddDate(0, 0, -2) }), "~2024-05-20", "[2024-05-14 10:30:00,2024-05-20 00:00:00]", ""},
+		{Time().Natural(), "昨天", "2024-05-15 00:00:00", ""},
+	})
+
+	// defaults are evaluated on each run, not when the filter is built
+	f := TimeRange().RightDefault("today")
+	setTestClock(t, time.Date(2024, 6, 1, 0, 0, 0, 0, timeLoc))
+	testRun(t, []runCase{
+		{f, "2024-05-01~", "[2024-05-01 00:00:00,2024-06-01 00:00:00]", ""},
+	})
+}
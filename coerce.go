@@ -0,0 +1,278 @@
+package filter
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Values decoded from JSON or passed by callers as any Go numeric kind are
+// coerced into the type of number filters. Conversion is done only when it
+// is lossless: a fractional part reports NumberHasFraction, a value out of
+// the range of the target type reports NumberOverflow, and an integer which
+// can not be represented exactly as float reports NumberPrecisionLoss.
+// Duration (as seconds), Flags (as a raw mask) and Bool (0 or 1) follow the
+// same policy.
+
+// maxCoerceExp is the largest exponent of a decimal string coerced into a
+// rational number, larger exponents overflow every fixed size type.
+const maxCoerceExp = 1000
+
+// decimalRat parse a decimal string as an exact rational number.
+func decimalRat(s string) (*big.Rat, string, bool) {
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return nil, "", false
+		}
+		if exp > maxCoerceExp {
+			return nil, "NumberOverflow", true
+		}
+		if exp < -maxCoerceExp {
+			return nil, "NumberHasFraction", true
+		}
+	}
+	d, err := ParseBigDecimal(s)
+	if err != nil {
+		return nil, "", false
+	}
+	return d.Rat(), "", true
+}
+
+// numberRat convert a numeric value to an exact rational number, strings and
+// json.Number must be decimal numbers. ok is false if v is not a number.
+func numberRat(v interface{}) (*big.Rat, string, bool) {
+	switch val := v.(type) {
+	case json.Number:
+		return decimalRat(string(val))
+	case string:
+		return decimalRat(strings.Trim(val, " \t\r\n"))
+	case *big.Rat:
+		return val, "", true
+	case *big.Int:
+		return new(big.Rat).SetInt(val), "", true
+	case *BigDecimal:
+		return val.Rat(), "", true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(rv.Int()), "", true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetUint64(rv.Uint()), "", true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) {
+			return nil, "", false
+		}
+		if math.IsInf(f, 0) {
+			return nil, "NumberOverflow", true
+		}
+		return new(big.Rat).SetFloat64(f), "", true
+	}
+	return nil, "", false
+}
+
+// coerceInt64 coerce a numeric value into an integer in [min, max].
+func coerceInt64(v interface{}, min, max int64) (int64, string, bool) {
+	r, word, ok := numberRat(v)
+	if !ok || word != "" {
+		return 0, word, ok
+	}
+	if !r.IsInt() {
+		return 0, "NumberHasFraction", true
+	}
+	n := r.Num()
+	if !n.IsInt64() || n.Int64() < min || n.Int64() > max {
+		return 0, "NumberOverflow", true
+	}
+	return n.Int64(), "", true
+}
+
+// coerceUint64 coerce a numeric value into an unsigned integer in [0, max].
+func coerceUint64(v interface{}, max uint64) (uint64, string, bool) {
+	r, word, ok := numberRat(v)
+	if !ok || word != "" {
+		return 0, word, ok
+	}
+	if !r.IsInt() {
+		return 0, "NumberHasFraction", true
+	}
+	n := r.Num()
+	if !n.IsUint64() || n.Uint64() > max {
+		return 0, "NumberOverflow", true
+	}
+	return n.Uint64(), "", true
+}
+
+// coerceFloat64 coerce a numeric value into a float of the specified bit
+// size. Floats and decimal strings are rounded to the nearest value like
+// strconv.ParseFloat, integers must be exactly representable.
+func coerceFloat64(v interface{}, bitSize int) (float64, string, bool) {
+	var s string
+	switch val := v.(type) {
+	case json.Number:
+		s = string(val)
+	case string:
+		s = strings.Trim(val, " \t\r\n")
+	default:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			if bitSize == 32 && !math.IsInf(f, 0) && math.Abs(f) > math.MaxFloat32 {
+				return 0, "NumberOverflow", true
+			}
+			return f, "", true
+		}
+
+		r, word, ok := numberRat(v)
+		if !ok || word != "" {
+			return 0, word, ok
+		}
+		var f float64
+		var exact bool
+		if bitSize == 32 {
+			f32, exact32 := r.Float32()
+			f, exact = float64(f32), exact32
+		} else {
+			f, exact = r.Float64()
+		}
+		if math.IsInf(f, 0) {
+			return 0, "NumberOverflow", true
+		}
+		if !exact {
+			return 0, "NumberPrecisionLoss", true
+		}
+		return f, "", true
+	}
+
+	f, err := strconv.ParseFloat(s, bitSize)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange && math.IsInf(f, 0) {
+			return 0, "NumberOverflow", true
+		}
+		return 0, "", false
+	}
+	return f, "", true
+}
+
+// coerceBigInt coerce a numeric value into a big int.
+func coerceBigInt(v interface{}) (*big.Int, string, bool) {
+	r, word, ok := numberRat(v)
+	if !ok || word != "" {
+		return nil, word, ok
+	}
+	if !r.IsInt() {
+		return nil, "NumberHasFraction", true
+	}
+	return new(big.Int).Set(r.Num()), "", true
+}
+
+// coerceDecimal coerce a numeric value into a decimal, floats are converted
+// from their shortest decimal representation.
+func coerceDecimal(v interface{}) (*BigDecimal, string, bool) {
+	switch val := v.(type) {
+	case json.Number:
+		d, err := ParseBigDecimal(string(val))
+		return d, "", err == nil
+	case string:
+		d, err := ParseBigDecimal(strings.Trim(val, " \t\r\n"))
+		return d, "", err == nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) {
+			return nil, "", false
+		}
+		if math.IsInf(f, 0) {
+			return nil, "NumberOverflow", true
+		}
+		bitSize := 64
+		if rv.Kind() == reflect.Float32 {
+			bitSize = 32
+		}
+		d, err := ParseBigDecimal(strconv.FormatFloat(f, 'g', -1, bitSize))
+		return d, "", err == nil
+	}
+
+	n, word, ok := coerceBigInt(v)
+	if !ok || word != "" {
+		return nil, word, ok
+	}
+	return &BigDecimal{n, 0}, "", true
+}
+
+// coerceItems return the items of a slice or array, eg: []interface{}
+// decoded from a JSON array.
+func coerceItems(v interface{}) ([]interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, true
+}
+
+// coerceRangeItems return the two sides of a closed range given as a two
+// items array like [1, 10], a nil side means the default value is used.
+func coerceRangeItems(v interface{}) (interface{}, interface{}, bool) {
+	items, ok := coerceItems(v)
+	if !ok || len(items) != 2 {
+		return nil, nil, false
+	}
+	return items[0], items[1], true
+}
+
+// coerceInt64Range coerce a two items array into a closed integer range.
+func coerceInt64Range(v interface{}, min, max, defaultLeft, defaultRight int64) (int64, int64, string, bool) {
+	leftItem, rightItem, ok := coerceRangeItems(v)
+	if !ok {
+		return 0, 0, "", false
+	}
+	left, right := defaultLeft, defaultRight
+	var word string
+	if leftItem != nil {
+		if left, word, ok = coerceInt64(leftItem, min, max); !ok || word != "" {
+			return 0, 0, word, ok
+		}
+	}
+	if rightItem != nil {
+		if right, word, ok = coerceInt64(rightItem, min, max); !ok || word != "" {
+			return 0, 0, word, ok
+		}
+	}
+	return left, right, "", left <= right
+}
+
+// coerceUint64Range coerce a two items array into a closed unsigned integer
+// range.
+func coerceUint64Range(v interface{}, max, defaultLeft, defaultRight uint64) (uint64, uint64, string, bool) {
+	leftItem, rightItem, ok := coerceRangeItems(v)
+	if !ok {
+		return 0, 0, "", false
+	}
+	left, right := defaultLeft, defaultRight
+	var word string
+	if leftItem != nil {
+		if left, word, ok = coerceUint64(leftItem, max); !ok || word != "" {
+			return 0, 0, word, ok
+		}
+	}
+	if rightItem != nil {
+		if right, word, ok = coerceUint64(rightItem, max); !ok || word != "" {
+			return 0, 0, word, ok
+		}
+	}
+	return left, right, "", left <= right
+}
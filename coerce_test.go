@@ -0,0 +1,42 @@
+package filter
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestCoerceNumbers(t *testing.T) {
+	testRun(t, []runCase{
+		{Int(), float64(42), "42", ""},
+		{Int(), json.Number("42"), "42", ""},
+		{Int(), json.Number("4.2e1"), "42", ""},
+		{Int(), 42.5, "", "NumberHasFraction"},
+		{Int(), true, "", "NotInt"},
+		{Int32(), int64(math.MaxInt32) + 1, "", "NumberOverflow"},
+		{Int64(), uint64(math.MaxUint64), "", "NumberOverflow"},
+		{Int64(), json.Number("1e400"), "", "NumberOverflow"},
+		{Uint32(), int8(-1), "", "NumberOverflow"},
+		{Uint64(), float32(7), "7", ""},
+		{Float64(), int64(1<<53 + 1), "", "NumberPrecisionLoss"},
+		{Float64(), json.Number("0.1"), "0.1", ""},
+		{Float32(), 1e39, "", "NumberOverflow"},
+		{BigInt(), json.Number("123456789012345678901234567890"), "123456789012345678901234567890", ""},
+		{BigInt(), 1.5, "", "NumberHasFraction"},
+		{Decimal(), 0.1, "0.1", ""},
+		{Decimal(), json.Number("-2.50"), "-2.50", ""},
+	})
+}
+
+func TestCoerceSetsAndRanges(t *testing.T) {
+	testRun(t, []runCase{
+		{IntSet(), []interface{}{float64(1), json.Number("2")}, "[1 2]", ""},
+		{IntSet(), []interface{}{1, 2.5}, "", "ItemNumberHasFraction"},
+		{Timestamp64Set(), []interface{}{1e20}, "", "ItemNumberOverflow"},
+		{DecimalSet(), []interface{}{0.5, json.Number("1")}, "[0.5 1]", ""},
+		{Int64Range(), []interface{}{float64(1), json.Number("10")}, "[1,10]", ""},
+		{Int64Range(), []interface{}{1, 2.5}, "", "NumberHasFraction"},
+		{Timestamp64Range().LeftDefault(0), []interface{}{nil, 5}, "[0,5]", ""},
+		{Timestamp64Range(), []interface{}{1, 2, 3}, "", "NotTimestamp64Range"},
+	})
+}
@@ -0,0 +1,341 @@
+package filter
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed five fields cron expression, occurrences are
+// evaluated on the wall clock of its location.
+type CronSchedule struct {
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+	loc     *time.Location
+}
+
+type CronFilter struct {
+	minInterval time.Duration
+	validators  []CronValidator
+	allowVals   allowList
+}
+
+type CronValidator func(paramName string, paramValue *CronSchedule) *Error
+
+var errInvalidCron = errors.New("invalid cron expression")
+
+// days to search for the next occurrence, long enough to cover leap years
+const cronSearchDays = 8 * 366
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronDayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// Cron return a cron expression filter, it accepts five fields expressions
+// like "*/5 * * * *" and macros like "@daily", and output *CronSchedule
+// evaluated in the filter's location.
+func Cron() *CronFilter {
+	f := new(CronFilter)
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *CronFilter) Allow(vals ...string) *CronFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *CronFilter) AllowSpecial(special Special, vals ...string) *CronFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *CronFilter) AllowSpecialFold(special Special, vals ...string) *CronFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// MinInterval set the minimum interval between two occurrences.
+func (f *CronFilter) MinInterval(d time.Duration) *CronFilter {
+	f.minInterval = d
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *CronFilter) AddValidator(validator CronValidator) *CronFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// ParseCron parse a five fields cron expression: minute, hour, day of month,
+// month and day of week. Names of months and days, "*", ranges, lists and
+// steps are supported, both 0 and 7 are sunday. If both day of month and day
+// of week are restricted, a day matching either one matches.
+func ParseCron(expr string, loc *time.Location) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errInvalidCron
+	}
+
+	s := &CronSchedule{loc: loc}
+	var err error
+	if s.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// parseCronField parse a cron field into a bit set.
+func parseCronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, errInvalidCron
+			}
+			rangePart, step = part[:i], n
+		}
+
+		var start, end int
+		if rangePart == "*" {
+			start, end = min, max
+		} else if i := strings.IndexByte(rangePart, '-'); i >= 0 {
+			var err error
+			if start, err = parseCronValue(rangePart[:i], names); err != nil {
+				return 0, err
+			}
+			if end, err = parseCronValue(rangePart[i+1:], names); err != nil {
+				return 0, err
+			}
+		} else {
+			var err error
+			if start, err = parseCronValue(rangePart, names); err != nil {
+				return 0, err
+			}
+			end = start
+			if step > 1 {
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return 0, errInvalidCron
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// parseCronValue parse a number or name in cron field.
+func parseCronValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errInvalidCron
+	}
+	return v, nil
+}
+
+// dayMatches check whether the date matches day of month, month and day of
+// week fields.
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	if s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// dayMinutes return the sorted minutes of day of occurrences.
+func (s *CronSchedule) dayMinutes() []int {
+	var minutes []int
+	for h := 0; h < 24; h++ {
+		if s.hour&(1<<uint(h)) == 0 {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if s.minute&(1<<uint(m)) != 0 {
+				minutes = append(minutes, h*60+m)
+			}
+		}
+	}
+	return minutes
+}
+
+// Next return the first occurrence after t, zero time if there is none.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.In(s.loc).Truncate(time.Minute).Add(time.Minute)
+	minutes := s.dayMinutes()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.loc)
+	from := t.Hour()*60 + t.Minute()
+	for i := 0; i < cronSearchDays; i++ {
+		if s.dayMatches(day) {
+			for _, m := range minutes {
+				if m < from {
+					continue
+				}
+				next := time.Date(day.Year(), day.Month(), day.Day(), m/60, m%60, 0, 0, s.loc)
+				if !next.Before(t) {
+					return next
+				}
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+		from = 0
+	}
+	return time.Time{}
+}
+
+// NextN return the next n occurrences after t.
+func (s *CronSchedule) NextN(t time.Time, n int) []time.Time {
+	var times []time.Time
+	for len(times) < n {
+		t = s.Next(t)
+		if t.IsZero() {
+			break
+		}
+		times = append(times, t)
+	}
+	return times
+}
+
+// Preview return the next n occurrences from now.
+func (s *CronSchedule) Preview(n int) []time.Time {
+	return s.NextN(now(), n)
+}
+
+// MinInterval return the minimum interval between two consecutive
+// occurrences on the wall clock, zero if there are less than two occurrences.
+func (s *CronSchedule) MinInterval() time.Duration {
+	minutes := s.dayMinutes()
+	if len(minutes) == 0 {
+		return 0
+	}
+	best := -1
+	for i := 1; i < len(minutes); i++ {
+		if gap := minutes[i] - minutes[i-1]; best < 0 || gap < best {
+			best = gap
+		}
+	}
+
+	// gap from the last occurrence of a day to the first of next matched day
+	day := time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	last := -1
+	for i := 0; i < cronSearchDays; i++ {
+		if s.dayMatches(day) {
+			if last >= 0 {
+				gap := (i-last)*24*60 - (minutes[len(minutes)-1] - minutes[0])
+				if best < 0 || gap < best {
+					best = gap
+				}
+			}
+			last = i
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	if best < 0 {
+		return 0
+	}
+	return time.Duration(best) * time.Minute
+}
+
+// Run make the filter running.
+func (f *CronFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var schedule *CronSchedule
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		s, err := ParseCron(val, timeLoc)
+		if err != nil {
+			goto parse_error
+		}
+		schedule = s
+	case *CronSchedule:
+		schedule = val
+	default:
+		goto parse_error
+	}
+
+	if schedule.Next(time.Date(2000, time.January, 1, 0, 0, 0, 0, schedule.loc)).IsZero() {
+		return nil, NewError(ErrorInvalidParam, paramName, "ImpossibleSchedule")
+	}
+	if f.minInterval > 0 {
+		if d := schedule.MinInterval(); d > 0 && d < f.minInterval {
+			return nil, NewError(ErrorInvalidParam, paramName, "IntervalTooShort")
+		}
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, schedule); err != nil {
+			return nil, err
+		}
+	}
+
+	return schedule, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotCron")
+}
@@ -0,0 +1,123 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	from := time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC) // Sunday
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"10-50/20 3 * * *", []string{"2024-09-01 03:10:00", "2024-09-01 03:30:00", "2024-09-01 03:50:00"}},
+		{"0 12 * sep-oct MON,wed", []string{"2024-09-02 12:00:00", "2024-09-04 12:00:00", "2024-09-09 12:00:00"}},
+		{"0 0 * * 7", []string{"2024-09-08 00:00:00", "2024-09-15 00:00:00", "2024-09-22 00:00:00"}},
+		{"0 0 * * 0", []string{"2024-09-08 00:00:00", "2024-09-15 00:00:00", "2024-09-22 00:00:00"}},
+		{"0 0 10 * fri", []string{"2024-09-06 00:00:00", "2024-09-10 00:00:00", "2024-09-13 00:00:00"}},
+		{"0 0 10 * *", []string{"2024-09-10 00:00:00", "2024-10-10 00:00:00", "2024-11-10 00:00:00"}},
+		{"@weekly", []string{"2024-09-08 00:00:00", "2024-09-15 00:00:00", "2024-09-22 00:00:00"}},
+		{"@hourly", []string{"2024-09-01 01:00:00", "2024-09-01 02:00:00", "2024-09-01 03:00:00"}},
+		{"@yearly", []string{"2025-01-01 00:00:00", "2026-01-01 00:00:00", "2027-01-01 00:00:00"}},
+	}
+	for _, tt := range tests {
+		s, err := ParseCron(tt.expr, time.UTC)
+		if err != nil {
+			t.Errorf("ParseCron(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if got := format(s.NextN(from, 3)); got != format(tt.want) {
+			t.Errorf("ParseCron(%q).NextN = %s, want %s", tt.expr, got, format(tt.want))
+		}
+	}
+
+	for _, expr := range []string{
+		"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *",
+		"0 0 * 13 *", "0 0 * * 8", "*/0 * * * *", "5-1 * * * *", "0 0 * foo *", "@never",
+	} {
+		if _, err := ParseCron(expr, time.UTC); err != errInvalidCron {
+			t.Errorf("ParseCron(%q) error = %v, want %v", expr, err, errInvalidCron)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		expr string
+		from time.Time
+		want []string
+	}{
+		{"0 0 31 * *", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			[]string{"2024-03-31 00:00:00", "2024-05-31 00:00:00", "2024-07-31 00:00:00"}},
+		{"0 0 29 2 *", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			[]string{"2028-02-29 00:00:00", "2032-02-29 00:00:00", "2036-02-29 00:00:00"}},
+		{"0 0 1 * *", time.Date(2024, 2, 29, 23, 59, 0, 0, time.UTC),
+			[]string{"2024-03-01 00:00:00", "2024-04-01 00:00:00", "2024-05-01 00:00:00"}},
+		{"30 23 28-31 2 *", time.Date(2023, 2, 28, 23, 30, 0, 0, time.UTC),
+			[]string{"2024-02-28 23:30:00", "2024-02-29 23:30:00", "2025-02-28 23:30:00"}},
+	}
+	for _, tt := range tests {
+		s, err := ParseCron(tt.expr, time.UTC)
+		if err != nil {
+			t.Errorf("ParseCron(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if got := format(s.NextN(tt.from, 3)); got != format(tt.want) {
+			t.Errorf("%q NextN(%s) = %s, want %s", tt.expr, format(tt.from), got, format(tt.want))
+		}
+	}
+
+	s, _ := ParseCron("0 0 30 2 *", time.UTC)
+	if next := s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); !next.IsZero() {
+		t.Errorf("Next of Feb 30 = %s, want zero time", format(next))
+	}
+}
+
+func TestCronMinInterval(t *testing.T) {
+	tests := []struct {
+		expr string
+		want time.Duration
+	}{
+		{"*/15 * * * *", 15 * time.Minute},
+		{"0 0,23 * * *", time.Hour},
+		{"0 9 * * mon-fri", 24 * time.Hour},
+		{"0 9 * * mon,thu", 3 * 24 * time.Hour},
+		{"0 0 29 2 *", 1461 * 24 * time.Hour},
+		{"0 0 30 2 *", 0},
+	}
+	for _, tt := range tests {
+		s, err := ParseCron(tt.expr, time.UTC)
+		if err != nil {
+			t.Errorf("ParseCron(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if got := s.MinInterval(); got != tt.want {
+			t.Errorf("%q MinInterval() = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestCronFilter(t *testing.T) {
+	for i, tt := range []struct {
+		f    *CronFilter
+		in   interface{}
+		word string
+	}{
+		{Cron(), "*/5 * * * *", ""},
+		{Cron(), "@daily", ""},
+		{Cron(), "61 * * * *", "NotCron"},
+		{Cron(), 5, "NotCron"},
+		{Cron(), "0 0 30 2 *", "ImpossibleSchedule"},
+		{Cron(), "0 0 31 4,6,9,11 *", "ImpossibleSchedule"},
+		{Cron().MinInterval(time.Hour), "*/30 * * * *", "IntervalTooShort"},
+		{Cron().MinInterval(2 * time.Hour), "0 0,23 * * *", "IntervalTooShort"},
+		{Cron().MinInterval(time.Hour), "0 * * * *", ""},
+		{Cron().AllowSpecial(None, "never"), "never", ""},
+	} {
+		_, err := tt.f.Run("p", tt.in)
+		if got := errWord(err); got != tt.word {
+			t.Errorf("#%d Run(%v) error = %v, want %q", i, tt.in, err, tt.word)
+		}
+	}
+}
@@ -0,0 +1,367 @@
+package filter
+
+import (
+	"math/big"
+	"strings"
+)
+
+type DecimalFilter struct {
+	strict          strictMode
+	rounding        bool
+	roundScale      int
+	roundMode       RoundingMode
+	clamp           bool
+	clampMin        *BigDecimal
+	clampMax        *BigDecimal
+	clampHook       ClampHook
+	clampErr        error
+	locale          *NumberLocale
+	chineseNumerals bool
+	validators      []DecimalValidator
+	allowVals       allowList
+}
+
+type DecimalValidator func(paramName string, paramValue *BigDecimal) *Error
+
+// Decimal return a arbitrary-precision decimal filter.
+func Decimal() *DecimalFilter {
+	f := new(DecimalFilter)
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *DecimalFilter) Allow(vals ...string) *DecimalFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *DecimalFilter) AllowSpecial(special Special, vals ...string) *DecimalFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *DecimalFilter) AllowSpecialFold(special Special, vals ...string) *DecimalFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Round round the output value to the specified scale with rounding mode.
+// Rounding is applied after validation.
+func (f *DecimalFilter) Round(scale int, mode RoundingMode) *DecimalFilter {
+	f.rounding = true
+	f.roundScale = scale
+	f.roundMode = mode
+	return f
+}
+
+// Clamp move param value out of [min, max] onto the nearest limit instead of
+// failing, validators run on the adjusted value and rounding is applied after
+// clamping.
+func (f *DecimalFilter) Clamp(min, max string) *DecimalFilter {
+	f.clamp = true
+	var minErr, maxErr error
+	f.clampMin, minErr = ParseBigDecimal(min)
+	f.clampMax, maxErr = ParseBigDecimal(max)
+	if minErr != nil || maxErr != nil {
+		f.clampErr = errInvalidDecimal
+	} else if f.clampMin.Cmp(f.clampMax) > 0 {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *DecimalFilter) OnClamp(hook ClampHook) *DecimalFilter {
+	f.clampHook = hook
+	return f
+}
+
+// Locale accept numbers with the decimal and grouping separators of locale,
+// full-width digits are folded too, eg: "1.234,56" with NumberLocaleDE.
+func (f *DecimalFilter) Locale(locale *NumberLocale) *DecimalFilter {
+	f.locale = locale
+	return f
+}
+
+// ChineseNumerals accept Chinese numerals like "一百二十" and "三点五".
+func (f *DecimalFilter) ChineseNumerals() *DecimalFilter {
+	f.chineseNumerals = true
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *DecimalFilter) Strict() *DecimalFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *DecimalFilter) Lenient() *DecimalFilter {
+	f.strict = strictOff
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *DecimalFilter) AddValidator(validator DecimalValidator) *DecimalFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// Precision valid whether param value fits in a SQL DECIMAL(precision, scale)
+// column: at most scale decimal places and precision-scale integer digits.
+func (f *DecimalFilter) Precision(precision, scale int) *DecimalFilter {
+	f.AddValidator(func(paramName string, paramValue *BigDecimal) *Error {
+		if paramValue.DecimalPlaces() > scale {
+			return NewError(ErrorInvalidParam, paramName, "TooManyDecimalPlaces")
+		}
+		if paramValue.IntDigits() > precision-scale {
+			return NewError(ErrorInvalidParam, paramName, "TooManyDigits")
+		}
+		return nil
+	})
+	return f
+}
+
+// Scale valid whether decimal places of param value is not larger than the
+// specified value.
+func (f *DecimalFilter) Scale(places int) *DecimalFilter {
+	f.AddValidator(func(paramName string, paramValue *BigDecimal) *Error {
+		if paramValue.DecimalPlaces() > places {
+			return NewError(ErrorInvalidParam, paramName, "TooManyDecimalPlaces")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxDigits valid whether the total count of digits of param value is not
+// larger than the specified value.
+func (f *DecimalFilter) MaxDigits(count int) *DecimalFilter {
+	f.AddValidator(func(paramName string, paramValue *BigDecimal) *Error {
+		if paramValue.Digits() > count {
+			return NewError(ErrorInvalidParam, paramName, "TooManyDigits")
+		}
+		return nil
+	})
+	return f
+}
+
+// Min valid param value should not be smaller than the specified value.
+func (f *DecimalFilter) Min(val string) *DecimalFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue *BigDecimal) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Cmp(d) < 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// Max valid param value should not be larger than the specified value.
+func (f *DecimalFilter) Max(val string) *DecimalFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue *BigDecimal) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Cmp(d) > 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// LargerThan valid param value should be larger than the specified value.
+func (f *DecimalFilter) LargerThan(val string) *DecimalFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue *BigDecimal) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Cmp(d) <= 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// SmallerThan valid param value should be smaller than the specified value.
+func (f *DecimalFilter) SmallerThan(val string) *DecimalFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue *BigDecimal) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Cmp(d) >= 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// Equal valid param value should be equal to the specified value.
+func (f *DecimalFilter) Equal(val string) *DecimalFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue *BigDecimal) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		c := paramValue.Cmp(d)
+		if c < 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooSmall")
+		}
+		if c > 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// Between valid param value should in the specified range.
+func (f *DecimalFilter) Between(min, max string) *DecimalFilter {
+	minVal, minErr := ParseBigDecimal(min)
+	maxVal, maxErr := ParseBigDecimal(max)
+	f.AddValidator(func(paramName string, paramValue *BigDecimal) *Error {
+		if minErr != nil || maxErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Cmp(minVal) < 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooSmall")
+		}
+		if paramValue.Cmp(maxVal) > 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// In valid param value should in the specified set.
+func (f *DecimalFilter) In(set []string) *DecimalFilter {
+	decSet, parseErr := parseDecimals(set)
+	f.AddValidator(func(paramName string, paramValue *BigDecimal) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		for _, v := range decSet {
+			if v.Cmp(paramValue) == 0 {
+				return nil
+			}
+		}
+		return NewError(ErrorInvalidParam, paramName, "NotInSet")
+	})
+	return f
+}
+
+// clampValue move v onto the nearest limit of clamp range.
+func (f *DecimalFilter) clampValue(paramName string, v *BigDecimal) *BigDecimal {
+	adjusted := v
+	if v.Cmp(f.clampMin) < 0 {
+		adjusted = f.clampMin
+	} else if v.Cmp(f.clampMax) > 0 {
+		adjusted = f.clampMax
+	}
+	if adjusted != v && f.clampHook != nil {
+		f.clampHook(paramName, v, adjusted)
+	}
+	return adjusted
+}
+
+// Run make the filter running.
+func (f *DecimalFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var decVal *BigDecimal
+	switch val := paramValue.(type) {
+	case string:
+		raw := val
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.check(raw, 10, true); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		if f.locale != nil || f.chineseNumerals {
+			var ok bool
+			if val, ok = normalizeNumber(val, f.locale, f.chineseNumerals); !ok {
+				goto parse_error
+			}
+		}
+		v, err := ParseBigDecimal(val)
+		if err != nil {
+			goto parse_error
+		}
+		decVal = v
+	case *BigDecimal:
+		decVal = val
+	case BigDecimal:
+		decVal = &val
+	case *big.Int:
+		decVal = &BigDecimal{new(big.Int).Set(val), 0}
+	case int:
+		decVal = NewBigDecimal(int64(val), 0)
+	case int64:
+		decVal = NewBigDecimal(val, 0)
+	default:
+		v, word, ok := coerceDecimal(val)
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		decVal = v
+	}
+
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		decVal = f.clampValue(paramName, decVal)
+	}
+	for _, validator := range f.validators {
+		if err := validator(paramName, decVal); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.rounding {
+		decVal = decVal.Round(f.roundScale, f.roundMode)
+	}
+
+	return decVal, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotDecimal")
+}
+
+// parseDecimals parse a list of decimal strings.
+func parseDecimals(vals []string) ([]*BigDecimal, error) {
+	decVals := make([]*BigDecimal, 0, len(vals))
+	for _, val := range vals {
+		d, err := ParseBigDecimal(val)
+		if err != nil {
+			return nil, err
+		}
+		decVals = append(decVals, d)
+	}
+	return decVals, nil
+}
@@ -0,0 +1,304 @@
+package filter
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type DurationFilter struct {
+	toSeconds  bool
+	validators []DurationValidator
+	allowVals  allowList
+}
+
+type DurationValidator func(paramName string, paramValue time.Duration) *Error
+
+var errInvalidDuration = errors.New("invalid duration")
+
+// duration units, day and week are always 24 hours and 7 days
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"μs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+}
+
+var durationTokenRegexp = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?|\.[0-9]+)(ns|us|µs|μs|ms|s|m|h|d|w)`)
+var isoDurationRegexp = regexp.MustCompile(`^P(?:([0-9]+(?:[.,][0-9]+)?)W)?(?:([0-9]+(?:[.,][0-9]+)?)D)?(?:T(?:([0-9]+(?:[.,][0-9]+)?)H)?(?:([0-9]+(?:[.,][0-9]+)?)M)?(?:([0-9]+(?:[.,][0-9]+)?)S)?)?$`)
+
+// Duration return a duration filter.
+func Duration() *DurationFilter {
+	f := new(DurationFilter)
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *DurationFilter) Allow(vals ...string) *DurationFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *DurationFilter) AllowSpecial(special Special, vals ...string) *DurationFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *DurationFilter) AllowSpecialFold(special Special, vals ...string) *DurationFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// ToSeconds output the duration as int64 whole seconds instead of time.Duration.
+func (f *DurationFilter) ToSeconds() *DurationFilter {
+	f.toSeconds = true
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *DurationFilter) AddValidator(validator DurationValidator) *DurationFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// Min valid param value should not be shorter than the specified duration.
+func (f *DurationFilter) Min(val time.Duration) *DurationFilter {
+	f.AddValidator(func(paramName string, paramValue time.Duration) *Error {
+		if paramValue < val {
+			return NewError(ErrorInvalidParam, paramName, "TooShort")
+		}
+		return nil
+	})
+	return f
+}
+
+// Max valid param value should not be longer than the specified duration.
+func (f *DurationFilter) Max(val time.Duration) *DurationFilter {
+	f.AddValidator(func(paramName string, paramValue time.Duration) *Error {
+		if paramValue > val {
+			return NewError(ErrorInvalidParam, paramName, "TooLong")
+		}
+		return nil
+	})
+	return f
+}
+
+// Between valid param value should in the specified range.
+func (f *DurationFilter) Between(min, max time.Duration) *DurationFilter {
+	f.AddValidator(func(paramName string, paramValue time.Duration) *Error {
+		if paramValue < min {
+			return NewError(ErrorInvalidParam, paramName, "TooShort")
+		}
+		if paramValue > max {
+			return NewError(ErrorInvalidParam, paramName, "TooLong")
+		}
+		return nil
+	})
+	return f
+}
+
+// MultipleOf valid param value should be a multiple of the specified duration.
+func (f *DurationFilter) MultipleOf(val time.Duration) *DurationFilter {
+	f.AddValidator(func(paramName string, paramValue time.Duration) *Error {
+		if val <= 0 {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue%val != 0 {
+			return NewError(ErrorInvalidParam, paramName, "NotMultiple")
+		}
+		return nil
+	})
+	return f
+}
+
+// Run make the filter running.
+func (f *DurationFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var durVal time.Duration
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		v, err := ParseDuration(val)
+		if err != nil {
+			goto parse_error
+		}
+		durVal = v
+	case time.Duration:
+		durVal = val
+	default:
+		// plain numbers are seconds, eg: 90 decoded from JSON as float64
+		v, word, ok := coerceInt64(val, math.MinInt64/int64(time.Second), math.MaxInt64/int64(time.Second))
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		durVal = time.Duration(v) * time.Second
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, durVal); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.toSeconds {
+		if durVal%time.Second != 0 {
+			return nil, NewError(ErrorInvalidParam, paramName, "NotWholeSeconds")
+		}
+		return int64(durVal / time.Second), nil
+	}
+	return durVal, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotDuration")
+}
+
+// ParseDuration parse a duration string, supported formats are:
+// Go syntax with day and week units ("1h30m", "3d", "2w"), ISO 8601
+// ("PT1H30M", "P3D", "P1W") and plain seconds ("90").
+// Calendar units (years and months) are not supported since their length varies.
+func ParseDuration(s string) (time.Duration, error) {
+	negative := false
+	if strings.HasPrefix(s, "-") {
+		negative = true
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	if s == "" {
+		return 0, errInvalidDuration
+	}
+
+	var d time.Duration
+	var err error
+	if s[0] == 'P' {
+		d, err = parseISODuration(s)
+	} else if v, e := strconv.ParseUint(s, 10, 63); e == nil {
+		if v > uint64(math.MaxInt64/int64(time.Second)) {
+			return 0, errInvalidDuration
+		}
+		d = time.Duration(v) * time.Second
+	} else {
+		d, err = parseUnitDuration(s)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if negative {
+		d = -d
+	}
+	return d, nil
+}
+
+// parseUnitDuration parse Go syntax durations extended with "d" and "w".
+func parseUnitDuration(s string) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
+	}
+	var total time.Duration
+	for s != "" {
+		m := durationTokenRegexp.FindStringSubmatch(s)
+		if m == nil {
+			return 0, errInvalidDuration
+		}
+		d, err := durationComponent(m[1], durationUnits[m[2]])
+		if err != nil {
+			return 0, err
+		}
+		if total > math.MaxInt64-d {
+			return 0, errInvalidDuration
+		}
+		total += d
+		s = s[len(m[0]):]
+	}
+	return total, nil
+}
+
+// parseISODuration parse ISO 8601 durations without year and month parts.
+func parseISODuration(s string) (time.Duration, error) {
+	m := isoDurationRegexp.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, errInvalidDuration
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total time.Duration
+	for i, unit := range units {
+		if m[i+1] == "" {
+			continue
+		}
+		d, err := durationComponent(strings.Replace(m[i+1], ",", ".", 1), unit)
+		if err != nil {
+			return 0, err
+		}
+		if total > math.MaxInt64-d {
+			return 0, errInvalidDuration
+		}
+		total += d
+	}
+	return total, nil
+}
+
+// durationComponent return num*unit exactly, num may have a fraction part.
+func durationComponent(num string, unit time.Duration) (time.Duration, error) {
+	intPart, fracPart := num, ""
+	if i := strings.IndexByte(num, '.'); i >= 0 {
+		intPart, fracPart = num[:i], num[i+1:]
+	}
+
+	var d time.Duration
+	if intPart != "" {
+		v, err := strconv.ParseInt(intPart, 10, 64)
+		if err != nil || v > math.MaxInt64/int64(unit) {
+			return 0, errInvalidDuration
+		}
+		d = time.Duration(v) * unit
+	}
+
+	// fraction digits beyond nanosecond precision are dropped
+	scale := time.Duration(1)
+	var frac time.Duration
+	for _, c := range fracPart {
+		if scale > unit {
+			break
+		}
+		frac = frac*10 + time.Duration(c-'0')
+		scale *= 10
+	}
+	if frac > 0 {
+		var fd time.Duration
+		if unit%scale == 0 {
+			fd = frac * (unit / scale)
+		} else {
+			fd = time.Duration(float64(frac) * (float64(unit) / float64(scale)))
+		}
+		if d > math.MaxInt64-fd {
+			return 0, errInvalidDuration
+		}
+		d += fd
+	}
+	return d, nil
+}
@@ -0,0 +1,62 @@
+package filter
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"1h30m", 90 * time.Minute, true},
+		{"3d", 72 * time.Hour, true},
+		{"2w", 14 * 24 * time.Hour, true},
+		{"1.5s", 1500 * time.Millisecond, true},
+		{"-90", -90 * time.Second, true},
+		{"0", 0, true},
+		{"PT1H30M", 90 * time.Minute, true},
+		{"P1DT12H", 36 * time.Hour, true},
+		{"PT0,5S", 500 * time.Millisecond, true},
+		{"P1W", 7 * 24 * time.Hour, true},
+		{"P1Y", 0, false},
+		{"P", 0, false},
+		{"PT", 0, false},
+		{"", 0, false},
+		{"1x", 0, false},
+		{"999999999999h", 0, false},
+		{"9223372036854775807", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestDurationFilter(t *testing.T) {
+	testRun(t, []runCase{
+		{Duration(), "1h", "1h0m0s", ""},
+		{Duration(), 90, "1m30s", ""},
+		{Duration(), float64(90), "1m30s", ""},
+		{Duration(), json.Number("90"), "1m30s", ""},
+		{Duration(), uint8(90), "1m30s", ""},
+		{Duration(), 1.5, "", "NumberHasFraction"},
+		{Duration(), int64(math.MaxInt64), "", "NumberOverflow"},
+		{Duration(), true, "", "NotDuration"},
+		{Duration(), time.Minute, "1m0s", ""},
+		{Duration(), "soon", "", "NotDuration"},
+		{Duration().ToSeconds(), "2m", "120", ""},
+		{Duration().ToSeconds(), "1.5s", "", "NotWholeSeconds"},
+		{Duration().Min(time.Minute), "30s", "", "TooShort"},
+		{Duration().Max(time.Hour), "2h", "", "TooLong"},
+		{Duration().MultipleOf(15 * time.Minute), "20m", "", "NotMultiple"},
+		{Duration().MultipleOf(0), "20m", "", "InvalidValidator"},
+		{Duration().AllowSpecial(Unlimited, "unlimited"), "unlimited", "unlimited", ""},
+	})
+}
@@ -14,7 +14,7 @@ const (
 type EmailFilter struct {
 	strcase    int
 	validators []EmailValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type EmailValidator func(paramName string, paramValue string) *Error
@@ -26,9 +26,24 @@ func Email() *EmailFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *EmailFilter) Allow(vals ...string) *EmailFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *EmailFilter) AllowSpecial(special Special, vals ...string) *EmailFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *EmailFilter) AllowSpecialFold(special Special, vals ...string) *EmailFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -136,10 +151,8 @@ func (f *EmailFilter) Run(paramName string, paramValue interface{}) (interface{}
 		return nil, NewError(ErrorInvalidParam, paramName, "NotEmail")
 	}
 	strVal = strings.Trim(strVal, " \t\r\n")
-	for _, allowVal := range f.allowVals {
-		if allowVal == strVal {
-			return strVal, nil
-		}
+	if special, ok := f.allowVals.match(strVal); ok {
+		return special, nil
 	}
 
 	switch f.strcase {
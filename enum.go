@@ -0,0 +1,165 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+)
+
+type EnumFilter struct {
+	members      enumMembers
+	onDeprecated EnumDeprecatedHandler
+	validators   []EnumValidator
+	allowVals    allowList
+}
+
+type EnumValidator func(paramName string, paramValue interface{}) *Error
+
+// EnumDeprecatedHandler is called when a deprecated enum member is used.
+type EnumDeprecatedHandler func(paramName string, memberName string)
+
+type enumMember struct {
+	name       string
+	value      interface{}
+	aliases    []string
+	deprecated bool
+}
+
+type enumMembers struct {
+	members      []*enumMember
+	ignoreCase   bool
+	acceptValues bool
+}
+
+// Enum return a enum filter which maps external names to internal values.
+func Enum() *EnumFilter {
+	f := new(EnumFilter)
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *EnumFilter) Allow(vals ...string) *EnumFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *EnumFilter) AllowSpecial(special Special, vals ...string) *EnumFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *EnumFilter) AllowSpecialFold(special Special, vals ...string) *EnumFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Member add a enum member, the name and aliases are mapped to value.
+func (f *EnumFilter) Member(name string, value interface{}, aliases ...string) *EnumFilter {
+	f.members.add(name, value, aliases, false)
+	return f
+}
+
+// DeprecatedMember add a deprecated enum member, it still validates but
+// OnDeprecated handler will be called when it is used.
+func (f *EnumFilter) DeprecatedMember(name string, value interface{}, aliases ...string) *EnumFilter {
+	f.members.add(name, value, aliases, true)
+	return f
+}
+
+// IgnoreCase match the member names and aliases case insensitively.
+func (f *EnumFilter) IgnoreCase() *EnumFilter {
+	f.members.ignoreCase = true
+	return f
+}
+
+// AcceptValues also accept the internal values of members as input, eg: a
+// value already mapped by a previous run.
+func (f *EnumFilter) AcceptValues() *EnumFilter {
+	f.members.acceptValues = true
+	return f
+}
+
+// OnDeprecated set the handler called when a deprecated member is used.
+func (f *EnumFilter) OnDeprecated(handler EnumDeprecatedHandler) *EnumFilter {
+	f.onDeprecated = handler
+	return f
+}
+
+// AddValidator add a custom validator to filter, the validator receives the
+// mapped value.
+func (f *EnumFilter) AddValidator(validator EnumValidator) *EnumFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// Run make the filter running.
+func (f *EnumFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	if val, ok := paramValue.(string); ok {
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		paramValue = val
+	}
+
+	member := f.members.find(paramValue)
+	if member == nil {
+		return nil, NewError(ErrorInvalidParam, paramName, "NotInSet")
+	}
+	if member.deprecated && f.onDeprecated != nil {
+		f.onDeprecated(paramName, member.name)
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, member.value); err != nil {
+			return nil, err
+		}
+	}
+
+	return member.value, nil
+}
+
+func (m *enumMembers) add(name string, value interface{}, aliases []string, deprecated bool) {
+	m.members = append(m.members, &enumMember{name, value, aliases, deprecated})
+}
+
+// find return the member matching the name or alias, the mapped value is
+// matched only if acceptValues is set.
+func (m *enumMembers) find(val interface{}) *enumMember {
+	if s, ok := val.(string); ok {
+		for _, member := range m.members {
+			if m.matchName(member.name, s) {
+				return member
+			}
+			for _, alias := range member.aliases {
+				if m.matchName(alias, s) {
+					return member
+				}
+			}
+		}
+	}
+	if !m.acceptValues {
+		return nil
+	}
+	for _, member := range m.members {
+		if reflect.DeepEqual(member.value, val) {
+			return member
+		}
+	}
+	return nil
+}
+
+func (m *enumMembers) matchName(name, val string) bool {
+	if m.ignoreCase {
+		return strings.EqualFold(name, val)
+	}
+	return name == val
+}
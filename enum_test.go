@@ -0,0 +1,41 @@
+package filter
+
+import "testing"
+
+func TestEnumFilter(t *testing.T) {
+	status := func() *EnumFilter {
+		return Enum().Member("active", 1, "on").Member("disabled", 2).DeprecatedMember("off", 2)
+	}
+	testRun(t, []runCase{
+		{status(), "active", "1", ""},
+		{status(), " on ", "1", ""},
+		{status(), "Active", "", "NotInSet"},
+		{status().IgnoreCase(), "Active", "1", ""},
+		{status(), "off", "2", ""},
+		{status(), 1, "", "NotInSet"},
+		{status(), "1", "", "NotInSet"},
+		{status().AcceptValues(), 1, "1", ""},
+		{status().AllowSpecial(All, "*"), "*", "all", ""},
+		{Enum().Member("a", "b").Member("b", "c"), "b", "c", ""},
+		{Enum().Member("a", "x"), "x", "", "NotInSet"},
+	})
+
+	var deprecated string
+	f := status().OnDeprecated(func(paramName, memberName string) { deprecated = memberName })
+	if _, err := f.Run("p", "off"); err != nil || deprecated != "off" {
+		t.Errorf("deprecated member = %q, %v", deprecated, err)
+	}
+}
+
+func TestEnumSetFilter(t *testing.T) {
+	colors := func() *EnumSetFilter {
+		return EnumSet().Member("red", "r").Member("green", "g")
+	}
+	testRun(t, []runCase{
+		{colors(), "red, green", "[r g]", ""},
+		{colors(), "red,r", "", "ItemNotInSet"},
+		{colors().AcceptValues(), "red,r", "[r r]", ""},
+		{colors(), []string{"green"}, "[g]", ""},
+		{colors().MaxCount(1), "red,green", "", "TooMany"},
+	})
+}
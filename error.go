@@ -69,6 +69,10 @@ var ErrorWordMap = map[string]map[string]string{
 		"TooSmall":  "too small",
 		"TooLarge":  "too large",
 
+		// Big Integer
+		"NotBigInt":   "not big int",
+		"TooManyBits": "too many bits",
+
 		// String
 		"NotString":       "not string",
 		"TooShort":        "too short",
@@ -91,16 +95,109 @@ var ErrorWordMap = map[string]map[string]string{
 		// Json
 		"NotJson": "not json",
 
+		// Bool
+		"NotBool":  "not bool",
+		"NotTrue":  "not true",
+		"NotFalse": "not false",
+
+		// Decimal
+		"NotDecimal":               "not decimal",
+		"TooManyDigits":            "too many digits",
+		"TooManyDecimalPlaces":     "too many decimal places",
+		"NotDecimalSet":            "not decimal set",
+		"ItemTooManyDigits":        "item has too many digits",
+		"ItemTooManyDecimalPlaces": "item has too many decimal places",
+		"NotDecimalRange":          "not decimal range",
+
 		// Timestamp、Time
 		"NotTimestamp": "not timestamp",
 		"NotTime":      "not date",
 		"TooEarly":     "too early",
 		"TooLate":      "too late",
 
+		// Time Detect
+		"AmbiguousTime": "ambiguous time",
+
+		// Period
+		"NotPeriod":        "not period",
+		"PeriodNotAllowed": "period unit not allowed",
+
+		// Business Calendar
+		"NotWorkday":           "not working day",
+		"Holiday":              "holiday",
+		"NotWorkingHours":      "not in working hours",
+		"ItemNotWorkday":       "item not working day",
+		"ItemHoliday":          "item is holiday",
+		"ItemNotWorkingHours":  "item not in working hours",
+		"LeftNotWorkday":       "left of range is not working day",
+		"LeftHoliday":          "left of range is holiday",
+		"LeftNotWorkingHours":  "left of range is not in working hours",
+		"RightNotWorkday":      "right of range is not working day",
+		"RightHoliday":         "right of range is holiday",
+		"RightNotWorkingHours": "right of range is not in working hours",
+
+		// Set Order
+		"NotAscending":  "not ascending",
+		"DuplicateItem": "duplicate item",
+		"GapTooSmall":   "gap too small",
+		"GapTooLarge":   "gap too large",
+		"SpanTooLarge":  "span too large",
+
+		// Time of Day, Weekday and Schedule
+		"NotTimeOfDay":      "not time of day",
+		"NotTimeOfDayRange": "not time of day range",
+		"OutOfRange":        "out of range",
+		"NotWeekdaySet":     "not weekday set",
+		"NotWeeklySchedule": "not weekly schedule",
+		"SlotTooShort":      "slot too short",
+		"SlotsOverlap":      "slots overlap",
+
+		// Cron and Recurrence Rule
+		"NotCron":            "not cron expression",
+		"NotRRule":           "not recurrence rule",
+		"ImpossibleSchedule": "impossible schedule",
+		"IntervalTooShort":   "interval too short",
+		"ScheduleTooSparse":  "schedule too sparse to evaluate",
+
+		// Strict Number
+		"NumberHasWhitespace":      "number has surrounding whitespace",
+		"NumberHasPlusSign":        "number has leading plus sign",
+		"NumberHasLeadingZero":     "number has leading zero",
+		"NumberHasUnderscore":      "number has underscore",
+		"NumberIsHexFloat":         "hex float is not allowed",
+		"NumberNotFinite":          "number is NaN or infinite",
+		"ItemNumberHasWhitespace":  "item has surrounding whitespace",
+		"ItemNumberHasPlusSign":    "item has leading plus sign",
+		"ItemNumberHasLeadingZero": "item has leading zero",
+		"ItemNumberHasUnderscore":  "item has underscore",
+		"ItemNumberIsHexFloat":     "item is a hex float",
+		"ItemNumberNotFinite":      "item is NaN or infinite",
+
+		// Number Coercion
+		"NumberOverflow":          "number is out of range",
+		"NumberHasFraction":       "number has fractional part",
+		"NumberPrecisionLoss":     "number can not be represented exactly",
+		"ItemNumberOverflow":      "item is out of range",
+		"ItemNumberHasFraction":   "item has fractional part",
+		"ItemNumberPrecisionLoss": "item can not be represented exactly",
+
+		// Duration
+		"NotDuration":     "not duration",
+		"NotMultiple":     "not a multiple of the specified value",
+		"NotWholeSeconds": "not whole seconds",
+
+		// Timestamp64
+		"NotTimestamp64":        "not timestamp64",
+		"NotTimestamp64Set":     "not timestamp64 set",
+		"NotTimestamp64Range":   "not timestamp64 range",
+		"TimestampOverflow":     "timestamp overflow",
+		"ItemTimestampOverflow": "item timestamp overflow",
+
 		// Range Distance
-		"TooNear":    "too near",
-		"TooFar":     "too far",
-		"WrongRange": "wrong range",
+		"TooNear":              "too near",
+		"TooFar":               "too far",
+		"WrongRange":           "wrong range",
+		"RangeEmptyAfterClamp": "range is empty after clamping",
 
 		// Integer Range
 		"NotIntRange":    "not int range",
@@ -132,6 +229,10 @@ var ErrorWordMap = map[string]map[string]string{
 		"ItemTooSmall": "item too small",
 		"ItemTooLarge": "item too large",
 
+		// Big Integer Set
+		"NotBigIntSet":    "not big int set",
+		"ItemTooManyBits": "item has too many bits",
+
 		// String Set
 		"NotStringSet":        "not string set",
 		"ItemTooShort":        "item too short",
@@ -142,6 +243,15 @@ var ErrorWordMap = map[string]map[string]string{
 		"ItemNotAlphaNumeric": "item not alphanumeric",
 		"ItemWrongFormat":     "item wrong format",
 
+		// Enum Set
+		"NotEnumSet": "not enum set",
+
+		// Flags
+		"NotFlags":      "not flags",
+		"UnknownFlag":   "unknown flag",
+		"MissingFlag":   "missing required flag",
+		"ConflictFlags": "conflicting flags",
+
 		// Email Set
 		"NotEmailSet": "not email set",
 
@@ -167,6 +277,10 @@ var ErrorWordMap = map[string]map[string]string{
 		"TooSmall":  "太小",
 		"TooLarge":  "太大",
 
+		// Big Integer
+		"NotBigInt":   "非大整数",
+		"TooManyBits": "二进制位数太多",
+
 		// String
 		"NotString":       "非字符串",
 		"TooShort":        "太短",
@@ -189,16 +303,109 @@ var ErrorWordMap = map[string]map[string]string{
 		// Json
 		"NotJson": "非JSON字符串",
 
+		// Bool
+		"NotBool":  "非布尔值",
+		"NotTrue":  "必须为真",
+		"NotFalse": "必须为假",
+
+		// Decimal
+		"NotDecimal":               "非decimal型",
+		"TooManyDigits":            "数字位数太多",
+		"TooManyDecimalPlaces":     "小数位数太多",
+		"NotDecimalSet":            "非decimal集合",
+		"ItemTooManyDigits":        "集合中元素数字位数太多",
+		"ItemTooManyDecimalPlaces": "集合中元素小数位数太多",
+		"NotDecimalRange":          "非decimal区间",
+
 		// Timestamp、Time
 		"NotTimestamp": "非时间戳",
 		"NotTime":      "非日期",
 		"TooEarly":     "太早",
 		"TooLate":      "太晚",
 
+		// Time Detect
+		"AmbiguousTime": "时间格式有歧义",
+
+		// Period
+		"NotPeriod":        "非周期",
+		"PeriodNotAllowed": "周期单位不允许",
+
+		// Business Calendar
+		"NotWorkday":           "非工作日",
+		"Holiday":              "节假日",
+		"NotWorkingHours":      "非工作时间",
+		"ItemNotWorkday":       "元素非工作日",
+		"ItemHoliday":          "元素为节假日",
+		"ItemNotWorkingHours":  "元素非工作时间",
+		"LeftNotWorkday":       "区间左值非工作日",
+		"LeftHoliday":          "区间左值为节假日",
+		"LeftNotWorkingHours":  "区间左值非工作时间",
+		"RightNotWorkday":      "区间右值非工作日",
+		"RightHoliday":         "区间右值为节假日",
+		"RightNotWorkingHours": "区间右值非工作时间",
+
+		// Set Order
+		"NotAscending":  "非升序",
+		"DuplicateItem": "元素重复",
+		"GapTooSmall":   "间隔太小",
+		"GapTooLarge":   "间隔太大",
+		"SpanTooLarge":  "跨度太大",
+
+		// Time of Day, Weekday and Schedule
+		"NotTimeOfDay":      "非时刻",
+		"NotTimeOfDayRange": "非时刻区间",
+		"OutOfRange":        "超出范围",
+		"NotWeekdaySet":     "非星期集合",
+		"NotWeeklySchedule": "非周计划",
+		"SlotTooShort":      "时段太短",
+		"SlotsOverlap":      "时段重叠",
+
+		// Cron and Recurrence Rule
+		"NotCron":            "非cron表达式",
+		"NotRRule":           "非重复规则",
+		"ImpossibleSchedule": "不可能触发的计划",
+		"IntervalTooShort":   "间隔太短",
+		"ScheduleTooSparse":  "计划过于稀疏无法计算",
+
+		// Strict Number
+		"NumberHasWhitespace":      "数字前后有空白",
+		"NumberHasPlusSign":        "数字有前导加号",
+		"NumberHasLeadingZero":     "数字有前导零",
+		"NumberHasUnderscore":      "数字含下划线",
+		"NumberIsHexFloat":         "不允许十六进制浮点数",
+		"NumberNotFinite":          "数字为NaN或无穷大",
+		"ItemNumberHasWhitespace":  "元素前后有空白",
+		"ItemNumberHasPlusSign":    "元素有前导加号",
+		"ItemNumberHasLeadingZero": "元素有前导零",
+		"ItemNumberHasUnderscore":  "元素含下划线",
+		"ItemNumberIsHexFloat":     "元素为十六进制浮点数",
+		"ItemNumberNotFinite":      "元素为NaN或无穷大",
+
+		// Number Coercion
+		"NumberOverflow":          "数字超出范围",
+		"NumberHasFraction":       "数字含小数部分",
+		"NumberPrecisionLoss":     "数字无法精确表示",
+		"ItemNumberOverflow":      "元素超出范围",
+		"ItemNumberHasFraction":   "元素含小数部分",
+		"ItemNumberPrecisionLoss": "元素无法精确表示",
+
+		// Duration
+		"NotDuration":     "非时长",
+		"NotMultiple":     "不是指定值的整数倍",
+		"NotWholeSeconds": "不是整秒数",
+
+		// Timestamp64
+		"NotTimestamp64":        "非64位时间戳",
+		"NotTimestamp64Set":     "非64位时间戳集合",
+		"NotTimestamp64Range":   "非64位时间戳区间",
+		"TimestampOverflow":     "时间戳溢出",
+		"ItemTimestampOverflow": "元素时间戳溢出",
+
 		// Range Distance
-		"TooNear":    "太近",
-		"TooFar":     "太远",
-		"WrongRange": "区间错误",
+		"TooNear":              "太近",
+		"TooFar":               "太远",
+		"WrongRange":           "区间错误",
+		"RangeEmptyAfterClamp": "区间截取后为空",
 
 		// Integer Range
 		"NotIntRange":    "非int区间",
@@ -230,6 +437,10 @@ var ErrorWordMap = map[string]map[string]string{
 		"ItemTooSmall": "集合中元素值太小",
 		"ItemTooLarge": "集合中元素值太大",
 
+		// Big Integer Set
+		"NotBigIntSet":    "非大整数集合",
+		"ItemTooManyBits": "集合中元素二进制位数太多",
+
 		// String Set
 		"NotStringSet":        "非字符串集合",
 		"ItemTooShort":        "集合中字符串太短",
@@ -240,6 +451,15 @@ var ErrorWordMap = map[string]map[string]string{
 		"ItemNotAlphaNumeric": "集合中字符串不是由纯字母和数字组成",
 		"ItemWrongFormat":     "集合中字符串格式错误",
 
+		// Enum Set
+		"NotEnumSet": "非枚举集合",
+
+		// Flags
+		"NotFlags":      "非标志位集合",
+		"UnknownFlag":   "未知标志位",
+		"MissingFlag":   "缺少必需的标志位",
+		"ConflictFlags": "标志位互相冲突",
+
 		// Email Set
 		"NotEmailSet": "非邮箱地址集合",
 
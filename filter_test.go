@@ -0,0 +1,86 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// errWord return the error word of err, empty if err is nil.
+func errWord(err *Error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Fields[len(err.Fields)-1]
+}
+
+type runner interface {
+	Run(paramName string, paramValue interface{}) (interface{}, *Error)
+}
+
+// runCase is a filter run with the expected output formatted by format, or
+// the expected error word.
+type runCase struct {
+	f    runner
+	in   interface{}
+	want string
+	word string
+}
+
+func testRun(t *testing.T, tests []runCase) {
+	t.Helper()
+	for i, tt := range tests {
+		got, err := tt.f.Run("p", tt.in)
+		if errWord(err) != tt.word {
+			t.Errorf("#%d Run(%v) error = %v, want %q", i, tt.in, err, tt.word)
+			continue
+		}
+		if err == nil {
+			if s := format(got); s != tt.want {
+				t.Errorf("#%d Run(%v) = %s, want %s", i, tt.in, s, tt.want)
+			}
+		}
+	}
+}
+
+// format format v like fmt.Sprint, times are formatted as "2006-01-02
+// 15:04:05" in their location and ranges as "[left,right)".
+func format(v interface{}) string {
+	switch val := v.(type) {
+	case time.Time:
+		return val.Format(LayoutDateTime)
+	case *time.Time:
+		return val.Format(LayoutDateTime)
+	case []*time.Time:
+		s := make([]string, len(val))
+		for i, t := range val {
+			s[i] = format(t)
+		}
+		return fmt.Sprint(s)
+	case []time.Time:
+		s := make([]string, len(val))
+		for i, t := range val {
+			s[i] = format(t)
+		}
+		return fmt.Sprint(s)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct {
+		r := rv.Elem()
+		left, right := r.FieldByName("Left"), r.FieldByName("Right")
+		leftClosed, rightClosed := r.FieldByName("LeftClosed"), r.FieldByName("RightClosed")
+		if left.IsValid() && right.IsValid() && leftClosed.IsValid() && rightClosed.IsValid() {
+			open, close := "(", ")"
+			if leftClosed.Bool() {
+				open = "["
+			}
+			if rightClosed.Bool() {
+				close = "]"
+			}
+			return open + format(left.Interface()) + "," + format(right.Interface()) + close
+		}
+	}
+	return fmt.Sprint(v)
+}
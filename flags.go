@@ -0,0 +1,210 @@
+package filter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+type FlagsFilter struct {
+	names      []string
+	bits       []uint64
+	delimiter  string
+	ignoreCase bool
+	validators []FlagsValidator
+	allowVals  allowList
+}
+
+type FlagsValidator func(paramName string, paramValue uint64) *Error
+
+// Flags return a bit flags filter, it accepts a list of flag names like
+// "read,write" or a raw integer mask, and output the uint64 mask.
+func Flags() *FlagsFilter {
+	f := new(FlagsFilter)
+	f.delimiter = ","
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *FlagsFilter) Allow(vals ...string) *FlagsFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *FlagsFilter) AllowSpecial(special Special, vals ...string) *FlagsFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *FlagsFilter) AllowSpecialFold(special Special, vals ...string) *FlagsFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Flag define a named flag with its bit mask, eg: Flag("write", 1<<1).
+func (f *FlagsFilter) Flag(name string, bit uint64) *FlagsFilter {
+	f.names = append(f.names, name)
+	f.bits = append(f.bits, bit)
+	return f
+}
+
+// Delimiter set the delimiter of flag list string.
+func (f *FlagsFilter) Delimiter(delimiter string) *FlagsFilter {
+	f.delimiter = delimiter
+	return f
+}
+
+// IgnoreCase match the flag names case insensitively.
+func (f *FlagsFilter) IgnoreCase() *FlagsFilter {
+	f.ignoreCase = true
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *FlagsFilter) AddValidator(validator FlagsValidator) *FlagsFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// Required valid whether all the specified flags are set.
+func (f *FlagsFilter) Required(names ...string) *FlagsFilter {
+	f.AddValidator(func(paramName string, paramValue uint64) *Error {
+		mask, ok := f.maskOf(names)
+		if !ok {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue&mask != mask {
+			return NewError(ErrorInvalidParam, paramName, "MissingFlag")
+		}
+		return nil
+	})
+	return f
+}
+
+// RequireOneOf valid whether at least one of the specified flags is set.
+func (f *FlagsFilter) RequireOneOf(names ...string) *FlagsFilter {
+	f.AddValidator(func(paramName string, paramValue uint64) *Error {
+		mask, ok := f.maskOf(names)
+		if !ok {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue&mask == 0 {
+			return NewError(ErrorInvalidParam, paramName, "MissingFlag")
+		}
+		return nil
+	})
+	return f
+}
+
+// Exclusive valid whether at most one of the specified flags is set.
+func (f *FlagsFilter) Exclusive(names ...string) *FlagsFilter {
+	f.AddValidator(func(paramName string, paramValue uint64) *Error {
+		if _, ok := f.maskOf(names); !ok {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		count := 0
+		for _, name := range names {
+			bit, _ := f.bitOf(name)
+			if paramValue&bit == bit {
+				count++
+			}
+		}
+		if count > 1 {
+			return NewError(ErrorInvalidParam, paramName, "ConflictFlags")
+		}
+		return nil
+	})
+	return f
+}
+
+// bitOf return the bit mask of the named flag.
+func (f *FlagsFilter) bitOf(name string) (uint64, bool) {
+	for i, n := range f.names {
+		if n == name || (f.ignoreCase && strings.EqualFold(n, name)) {
+			return f.bits[i], true
+		}
+	}
+	return 0, false
+}
+
+// maskOf return the combined bit mask of the named flags.
+func (f *FlagsFilter) maskOf(names []string) (uint64, bool) {
+	var mask uint64
+	for _, name := range names {
+		bit, ok := f.bitOf(name)
+		if !ok {
+			return 0, false
+		}
+		mask |= bit
+	}
+	return mask, true
+}
+
+// knownMask return the combined bit mask of all defined flags.
+func (f *FlagsFilter) knownMask() uint64 {
+	var mask uint64
+	for _, bit := range f.bits {
+		mask |= bit
+	}
+	return mask
+}
+
+// Run make the filter running.
+func (f *FlagsFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var mask uint64
+	var fields []string
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if v, err := strconv.ParseUint(val, 0, 64); err == nil {
+			mask = v
+		} else if val != "" {
+			fields = strings.Split(val, f.delimiter)
+		}
+	case []string:
+		fields = val
+	case uint64:
+		mask = val
+	default:
+		v, word, ok := coerceUint64(val, math.MaxUint64)
+		if !ok {
+			return nil, NewError(ErrorInvalidParam, paramName, "NotFlags")
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		mask = v
+	}
+
+	for _, field := range fields {
+		field = strings.Trim(field, " \t\r\n")
+		bit, ok := f.bitOf(field)
+		if !ok {
+			return nil, NewError(ErrorInvalidParam, paramName, "UnknownFlag")
+		}
+		mask |= bit
+	}
+	if mask&^f.knownMask() != 0 {
+		return nil, NewError(ErrorInvalidParam, paramName, "UnknownFlag")
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, mask); err != nil {
+			return nil, err
+		}
+	}
+
+	return mask, nil
+}
@@ -0,0 +1,34 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlagsFilter(t *testing.T) {
+	perms := func() *FlagsFilter {
+		return Flags().Flag("read", 1).Flag("write", 2).Flag("exec", 4)
+	}
+	testRun(t, []runCase{
+		{perms(), "read,write", "3", ""},
+		{perms(), " exec ", "4", ""},
+		{perms(), "", "0", ""},
+		{perms(), "5", "5", ""},
+		{perms(), "0x6", "6", ""},
+		{perms(), "8", "", "UnknownFlag"},
+		{perms(), "read,delete", "", "UnknownFlag"},
+		{perms(), "READ", "", "UnknownFlag"},
+		{perms().IgnoreCase(), "READ", "1", ""},
+		{perms().Delimiter("|"), "read|exec", "5", ""},
+		{perms(), []string{"write"}, "2", ""},
+		{perms(), -1, "", "NumberOverflow"},
+		{perms(), float64(3), "3", ""},
+		{perms(), json.Number("5"), "5", ""},
+		{perms(), 1.5, "", "NumberHasFraction"},
+		{perms(), true, "", "NotFlags"},
+		{perms().Required("read"), "write", "", "MissingFlag"},
+		{perms().RequireOneOf("read", "write"), "exec", "", "MissingFlag"},
+		{perms().Exclusive("read", "exec"), "read,exec", "", "ConflictFlags"},
+		{perms().Required("delete"), "read", "", "InvalidValidator"},
+	})
+}
@@ -7,8 +7,17 @@ import (
 )
 
 type Float32Filter struct {
-	validators []Float32Validator
-	allowVals  []string
+	strict          strictMode
+	clamp           bool
+	clampMin        float32
+	clampMax        float32
+	clampErr        error
+	clampHook       ClampHook
+	units           NumberUnits
+	locale          *NumberLocale
+	chineseNumerals bool
+	validators      []Float32Validator
+	allowVals       allowList
 }
 
 type Float32Validator func(paramName string, paramValue float32) *Error
@@ -19,9 +28,77 @@ func Float32() *Float32Filter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *Float32Filter) Allow(vals ...string) *Float32Filter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *Float32Filter) AllowSpecial(special Special, vals ...string) *Float32Filter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *Float32Filter) AllowSpecialFold(special Special, vals ...string) *Float32Filter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Strict reject NaN, Inf, hex floats, underscores, a leading "+", leading
+// zeros and surrounding whitespace, see SetStrictNumbers.
+func (f *Float32Filter) Strict() *Float32Filter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *Float32Filter) Lenient() *Float32Filter {
+	f.strict = strictOff
+	return f
+}
+
+// Clamp move param value out of [min, max] onto the nearest limit instead of
+// failing, validators run on the adjusted value.
+func (f *Float32Filter) Clamp(min, max float32) *Float32Filter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *Float32Filter) OnClamp(hook ClampHook) *Float32Filter {
+	f.clampHook = hook
+	return f
+}
+
+// Units allow values with unit suffixes like "10MB" or "80%", values are
+// normalized to the base unit before validation, see ByteUnits, SIUnits and
+// PercentUnits.
+func (f *Float32Filter) Units(units ...NumberUnits) *Float32Filter {
+	f.units = f.units.merge(units...)
+	return f
+}
+
+// Locale accept numbers with the decimal and grouping separators of locale,
+// full-width digits are folded too, eg: "1.234,56" with NumberLocaleDE.
+func (f *Float32Filter) Locale(locale *NumberLocale) *Float32Filter {
+	f.locale = locale
+	return f
+}
+
+// ChineseNumerals accept Chinese numerals like "一百二十" and "三点五".
+func (f *Float32Filter) ChineseNumerals() *Float32Filter {
+	f.chineseNumerals = true
 	return f
 }
 
@@ -131,6 +208,20 @@ func (f *Float32Filter) DecimalPlace(length int) *Float32Filter {
 	return f
 }
 
+// clampValue move v onto the nearest limit of clamp range.
+func (f *Float32Filter) clampValue(paramName string, v float32) float32 {
+	adjusted := v
+	if v < f.clampMin {
+		adjusted = f.clampMin
+	} else if v > f.clampMax {
+		adjusted = f.clampMax
+	}
+	if adjusted != v && f.clampHook != nil {
+		f.clampHook(paramName, v, adjusted)
+	}
+	return adjusted
+}
+
 // Run make the filter running.
 func (f *Float32Filter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -140,12 +231,31 @@ func (f *Float32Filter) Run(paramName string, paramValue interface{}) (interface
 	var floatVal float32
 	switch val := paramValue.(type) {
 	case string:
+		raw := val
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.check(raw, 10, true); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		if f.locale != nil || f.chineseNumerals {
+			var ok bool
+			if val, ok = normalizeNumber(val, f.locale, f.chineseNumerals); !ok {
+				goto parse_error
 			}
 		}
+		if r, matched, ok := f.units.parse(val); matched {
+			if !ok {
+				goto parse_error
+			}
+			v, word := ratFloat(r, 32)
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, word)
+			}
+			floatVal = float32(v)
+			break
+		}
 		v, err := strconv.ParseFloat(val, 32)
 		if err != nil {
 			goto parse_error
@@ -155,11 +265,33 @@ func (f *Float32Filter) Run(paramName string, paramValue interface{}) (interface
 		}
 		floatVal = float32(v)
 	case float32:
+		if word := f.strict.checkFloat(float64(val)); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
 		floatVal = val
 	default:
-		goto parse_error
+		v, word, ok := coerceFloat64(val, 32)
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		if word := f.strict.checkFloat(v); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		floatVal = float32(v)
 	}
 
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if math.IsNaN(float64(floatVal)) {
+			return nil, NewError(ErrorInvalidParam, paramName, "NumberNotFinite")
+		}
+		floatVal = f.clampValue(paramName, floatVal)
+	}
 	for _, validator := range f.validators {
 		if err := validator(paramName, floatVal); err != nil {
 			return nil, err
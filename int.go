@@ -3,12 +3,23 @@ package filter
 import (
 	"strconv"
 	"strings"
+
+	"github.com/go-apibox/types"
 )
 
 type IntFilter struct {
-	base       int
-	validators []IntValidator
-	allowVals  []string
+	base            int
+	strict          strictMode
+	clamp           bool
+	clampMin        int
+	clampMax        int
+	clampErr        error
+	clampHook       ClampHook
+	units           NumberUnits
+	locale          *NumberLocale
+	chineseNumerals bool
+	validators      []IntValidator
+	allowVals       allowList
 }
 
 type IntValidator func(paramName string, paramValue int) *Error
@@ -20,9 +31,24 @@ func Int() *IntFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *IntFilter) Allow(vals ...string) *IntFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *IntFilter) AllowSpecial(special Special, vals ...string) *IntFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *IntFilter) AllowSpecialFold(special Special, vals ...string) *IntFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -35,6 +61,59 @@ func (f *IntFilter) Base(base int) *IntFilter {
 	return f
 }
 
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *IntFilter) Strict() *IntFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *IntFilter) Lenient() *IntFilter {
+	f.strict = strictOff
+	return f
+}
+
+// Clamp move param value out of [min, max] onto the nearest limit instead of
+// failing, validators run on the adjusted value.
+func (f *IntFilter) Clamp(min, max int) *IntFilter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *IntFilter) OnClamp(hook ClampHook) *IntFilter {
+	f.clampHook = hook
+	return f
+}
+
+// Units allow values with unit suffixes like "10MB" or "80%", values are
+// normalized to the base unit before validation, see ByteUnits, SIUnits and
+// PercentUnits.
+func (f *IntFilter) Units(units ...NumberUnits) *IntFilter {
+	f.units = f.units.merge(units...)
+	return f
+}
+
+// Locale accept numbers with the decimal and grouping separators of locale,
+// full-width digits are folded too, eg: "1.234,56" with NumberLocaleDE.
+func (f *IntFilter) Locale(locale *NumberLocale) *IntFilter {
+	f.locale = locale
+	return f
+}
+
+// ChineseNumerals accept Chinese numerals like "一百二十" and "三点五".
+func (f *IntFilter) ChineseNumerals() *IntFilter {
+	f.chineseNumerals = true
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *IntFilter) AddValidator(validator IntValidator) *IntFilter {
 	f.validators = append(f.validators, validator)
@@ -127,6 +206,20 @@ func (f *IntFilter) In(set []int) *IntFilter {
 	return f
 }
 
+// clampValue move v onto the nearest limit of clamp range.
+func (f *IntFilter) clampValue(paramName string, v int) int {
+	adjusted := v
+	if v < f.clampMin {
+		adjusted = f.clampMin
+	} else if v > f.clampMax {
+		adjusted = f.clampMax
+	}
+	if adjusted != v && f.clampHook != nil {
+		f.clampHook(paramName, v, adjusted)
+	}
+	return adjusted
+}
+
 // Run make the filter running.
 func (f *IntFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -136,23 +229,55 @@ func (f *IntFilter) Run(paramName string, paramValue interface{}) (interface{},
 	var intVal int
 	switch val := paramValue.(type) {
 	case string:
+		raw := val
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.check(raw, f.base, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		if f.locale != nil || f.chineseNumerals {
+			var ok bool
+			if val, ok = normalizeNumber(val, f.locale, f.chineseNumerals); !ok {
+				goto parse_error
+			}
+		}
+		if r, matched, ok := f.units.parse(val); matched {
+			if !ok {
+				goto parse_error
+			}
+			v, word, _ := coerceInt64(r, int64(types.MinInt), int64(types.MaxInt))
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, word)
 			}
+			intVal = int(v)
+			break
 		}
 		v, err := strconv.ParseInt(val, f.base, 0)
-		if err != nil {
+		if err != nil && !(f.clamp && isRangeErr(err)) {
 			goto parse_error
 		}
 		intVal = int(v)
 	case int:
 		intVal = val
 	default:
-		goto parse_error
+		v, word, ok := coerceInt64(val, int64(types.MinInt), int64(types.MaxInt))
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		intVal = int(v)
 	}
 
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		intVal = f.clampValue(paramName, intVal)
+	}
 	for _, validator := range f.validators {
 		if err := validator(paramName, intVal); err != nil {
 			return nil, err
@@ -7,9 +7,18 @@ import (
 )
 
 type Int32Filter struct {
-	base       int
-	validators []Int32Validator
-	allowVals  []string
+	base            int
+	strict          strictMode
+	clamp           bool
+	clampMin        int32
+	clampMax        int32
+	clampErr        error
+	clampHook       ClampHook
+	units           NumberUnits
+	locale          *NumberLocale
+	chineseNumerals bool
+	validators      []Int32Validator
+	allowVals       allowList
 }
 
 type Int32Validator func(paramName string, paramValue int32) *Error
@@ -21,9 +30,24 @@ func Int32() *Int32Filter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *Int32Filter) Allow(vals ...string) *Int32Filter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *Int32Filter) AllowSpecial(special Special, vals ...string) *Int32Filter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *Int32Filter) AllowSpecialFold(special Special, vals ...string) *Int32Filter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -36,6 +60,59 @@ func (f *Int32Filter) Base(base int) *Int32Filter {
 	return f
 }
 
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *Int32Filter) Strict() *Int32Filter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *Int32Filter) Lenient() *Int32Filter {
+	f.strict = strictOff
+	return f
+}
+
+// Clamp move param value out of [min, max] onto the nearest limit instead of
+// failing, validators run on the adjusted value.
+func (f *Int32Filter) Clamp(min, max int32) *Int32Filter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *Int32Filter) OnClamp(hook ClampHook) *Int32Filter {
+	f.clampHook = hook
+	return f
+}
+
+// Units allow values with unit suffixes like "10MB" or "80%", values are
+// normalized to the base unit before validation, see ByteUnits, SIUnits and
+// PercentUnits.
+func (f *Int32Filter) Units(units ...NumberUnits) *Int32Filter {
+	f.units = f.units.merge(units...)
+	return f
+}
+
+// Locale accept numbers with the decimal and grouping separators of locale,
+// full-width digits are folded too, eg: "1.234,56" with NumberLocaleDE.
+func (f *Int32Filter) Locale(locale *NumberLocale) *Int32Filter {
+	f.locale = locale
+	return f
+}
+
+// ChineseNumerals accept Chinese numerals like "一百二十" and "三点五".
+func (f *Int32Filter) ChineseNumerals() *Int32Filter {
+	f.chineseNumerals = true
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *Int32Filter) AddValidator(validator Int32Validator) *Int32Filter {
 	f.validators = append(f.validators, validator)
@@ -128,6 +205,20 @@ func (f *Int32Filter) In(set []int32) *Int32Filter {
 	return f
 }
 
+// clampValue move v onto the nearest limit of clamp range.
+func (f *Int32Filter) clampValue(paramName string, v int32) int32 {
+	adjusted := v
+	if v < f.clampMin {
+		adjusted = f.clampMin
+	} else if v > f.clampMax {
+		adjusted = f.clampMax
+	}
+	if adjusted != v && f.clampHook != nil {
+		f.clampHook(paramName, v, adjusted)
+	}
+	return adjusted
+}
+
 // Run make the filter running.
 func (f *Int32Filter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -137,26 +228,55 @@ func (f *Int32Filter) Run(paramName string, paramValue interface{}) (interface{}
 	var intVal int32
 	switch val := paramValue.(type) {
 	case string:
+		raw := val
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.check(raw, f.base, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		if f.locale != nil || f.chineseNumerals {
+			var ok bool
+			if val, ok = normalizeNumber(val, f.locale, f.chineseNumerals); !ok {
+				goto parse_error
 			}
 		}
-		v, err := strconv.ParseInt(val, f.base, 0)
-		if err != nil {
-			goto parse_error
+		if r, matched, ok := f.units.parse(val); matched {
+			if !ok {
+				goto parse_error
+			}
+			v, word, _ := coerceInt64(r, math.MinInt32, math.MaxInt32)
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, word)
+			}
+			intVal = int32(v)
+			break
 		}
-		if v > math.MaxInt32 {
+		v, err := strconv.ParseInt(val, f.base, 32)
+		if err != nil && !(f.clamp && isRangeErr(err)) {
 			goto parse_error
 		}
 		intVal = int32(v)
 	case int32:
 		intVal = val
 	default:
-		goto parse_error
+		v, word, ok := coerceInt64(val, math.MinInt32, math.MaxInt32)
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		intVal = int32(v)
 	}
 
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		intVal = f.clampValue(paramName, intVal)
+	}
 	for _, validator := range f.validators {
 		if err := validator(paramName, intVal); err != nil {
 			return nil, err
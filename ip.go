@@ -7,7 +7,7 @@ import (
 
 type IPFilter struct {
 	validators []IPValidator
-	allowVals  []string
+	allowVals  allowList
 	toString   bool
 }
 
@@ -19,9 +19,24 @@ func IP() *IPFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *IPFilter) Allow(vals ...string) *IPFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *IPFilter) AllowSpecial(special Special, vals ...string) *IPFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *IPFilter) AllowSpecialFold(special Special, vals ...string) *IPFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -71,10 +86,8 @@ func (f *IPFilter) Run(paramName string, paramValue interface{}) (interface{}, *
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		v := net.ParseIP(val)
 		if v == nil {
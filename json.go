@@ -9,7 +9,7 @@ import (
 type JsonFilter struct {
 	outVar     interface{}
 	validators []JsonValidator
-	allowVals  []string
+	allowVals  allowList
 	toString   bool
 }
 
@@ -27,9 +27,24 @@ func (f *JsonFilter) Output(outVar interface{}) *JsonFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *JsonFilter) Allow(vals ...string) *JsonFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *JsonFilter) AllowSpecial(special Special, vals ...string) *JsonFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *JsonFilter) AllowSpecialFold(special Special, vals ...string) *JsonFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -58,10 +73,8 @@ func (f *JsonFilter) Run(paramName string, paramValue interface{}) (interface{},
 		goto parse_error
 	}
 	strVal = strings.Trim(strVal, " \t\r\n")
-	for _, allowVal := range f.allowVals {
-		if allowVal == strVal {
-			return strVal, nil
-		}
+	if special, ok := f.allowVals.match(strVal); ok {
+		return special, nil
 	}
 
 	if f.outVar != nil {
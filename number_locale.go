@@ -0,0 +1,294 @@
+package filter
+
+import (
+	"math/big"
+	"strings"
+)
+
+// NumberLocale is the decimal and grouping separators of numbers in a
+// locale, groups of the integer part must be three digits.
+type NumberLocale struct {
+	Decimal rune
+	Group   []rune
+}
+
+// predefined number locales
+var (
+	NumberLocaleEN = &NumberLocale{'.', []rune{','}}                     // 1,234,567.89
+	NumberLocaleZH = &NumberLocale{'.', []rune{','}}                     // 1,234,567.89
+	NumberLocaleDE = &NumberLocale{',', []rune{'.'}}                     // 1.234.567,89
+	NumberLocaleFR = &NumberLocale{',', []rune{' ', '\u00a0', '\u202f'}} // 1 234 567,89
+	NumberLocaleCH = &NumberLocale{'.', []rune{'\''}}                    // 1'234'567.89
+)
+
+var chineseDigits = map[rune]int64{
+	'零': 0, '〇': 0, '一': 1, '壹': 1, '二': 2, '贰': 2, '两': 2, '三': 3, '叁': 3,
+	'四': 4, '肆': 4, '五': 5, '伍': 5, '六': 6, '陆': 6, '七': 7, '柒': 7,
+	'八': 8, '捌': 8, '九': 9, '玖': 9,
+}
+
+var chineseUnits = map[rune]int64{
+	'十': 10, '拾': 10, '百': 100, '佰': 100, '千': 1000, '仟': 1000,
+	'万': 1e4, '萬': 1e4, '亿': 1e8, '億': 1e8,
+}
+
+// foldWidth fold full-width ASCII characters and ideographic space to their
+// ASCII forms, eg: "１２３" to "123".
+func foldWidth(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '！' && r <= '～':
+			return r - '！' + '!'
+		case r == '　':
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// isGroup check whether r is a grouping separator of the locale.
+func (l *NumberLocale) isGroup(r rune) bool {
+	for _, g := range l.Group {
+		if r == g {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize convert the leading number of s to the canonical form with "."
+// as the decimal separator and no grouping, the rest of s like a unit
+// suffix or an exponent is kept.
+func (l *NumberLocale) normalize(s string) (string, bool) {
+	runes := []rune(foldWidth(s))
+	var b strings.Builder
+	i := 0
+	if i < len(runes) && (runes[i] == '-' || runes[i] == '+') {
+		b.WriteRune(runes[i])
+		i++
+	}
+
+	digits, groups := 0, 0
+	checkGroups := func() bool {
+		return groups == 0 || digits == 3
+	}
+	for ; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			digits++
+			continue
+		case r == l.Decimal:
+			if !checkGroups() {
+				return "", false
+			}
+			b.WriteByte('.')
+			i++
+			for ; i < len(runes) && runes[i] >= '0' && runes[i] <= '9'; i++ {
+				b.WriteRune(runes[i])
+			}
+			b.WriteString(string(runes[i:]))
+			return b.String(), true
+		case l.isGroup(r) && i+1 < len(runes) && runes[i+1] >= '0' && runes[i+1] <= '9':
+			if digits == 0 || digits > 3 || (groups > 0 && digits != 3) {
+				return "", false
+			}
+			digits = 0
+			groups++
+			continue
+		}
+		break
+	}
+	if !checkGroups() {
+		return "", false
+	}
+	b.WriteString(string(runes[i:]))
+	return b.String(), true
+}
+
+// hasChineseNumeral check whether s contains Chinese numerals.
+func hasChineseNumeral(s string) bool {
+	for _, r := range s {
+		if _, ok := chineseDigits[r]; ok {
+			return true
+		}
+		if _, ok := chineseUnits[r]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parseChineseNumber convert a Chinese numeral like "一百二十", "两千零五",
+// "三点一四", "负五" or "1.5万" to the canonical decimal form. Colloquial
+// forms like "一百二" (120) and "两万五" (25000) are supported.
+func parseChineseNumber(s string) (string, bool) {
+	sign := ""
+	if strings.HasPrefix(s, "负") {
+		sign, s = "-", strings.TrimPrefix(s, "负")
+	} else if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	intPart, fracPart := s, ""
+	hasPoint := false
+	if i := strings.Index(s, "点"); i >= 0 {
+		intPart, fracPart, hasPoint = s[:i], s[i+len("点"):], true
+	}
+
+	value, places, ok := parseChineseInteger(intPart)
+	if !ok {
+		return "", false
+	}
+	if hasPoint {
+		if !value.IsInt() || fracPart == "" {
+			return "", false
+		}
+		frac, ok := chineseDigitString(fracPart)
+		if !ok {
+			return "", false
+		}
+		return sign + value.Num().String() + "." + frac, true
+	}
+	if value.IsInt() {
+		return sign + value.Num().String(), true
+	}
+	return sign + value.FloatString(places), true
+}
+
+// chineseDigitString convert a sequence of Chinese or ASCII digits like
+// "二〇二六" to ASCII digits.
+func chineseDigitString(s string) (string, bool) {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		} else if d, ok := chineseDigits[r]; ok {
+			b.WriteByte(byte('0' + d))
+		} else {
+			return "", false
+		}
+	}
+	return b.String(), b.Len() > 0
+}
+
+// parseChineseInteger parse the integer part of a Chinese numeral, ASCII
+// numbers like "1.5" are allowed before units. places is the number of
+// decimal places of ASCII numbers.
+func parseChineseInteger(s string) (*big.Rat, int, bool) {
+	if digits, ok := chineseDigitString(s); ok {
+		r, _ := new(big.Rat).SetString(digits)
+		return r, 0, true
+	}
+
+	runes := []rune(s)
+	result, section := new(big.Rat), new(big.Rat)
+	var number *big.Rat
+	var lastUnit, prevUnit int64
+	places := 0
+	wanSeen, hasNumber := false, false
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if (r >= '0' && r <= '9') || r == '.' {
+			j := i
+			for j < len(runes) && ((runes[j] >= '0' && runes[j] <= '9') || runes[j] == '.') {
+				j++
+			}
+			token := string(runes[i:j])
+			tok, word, ok := decimalRat(token)
+			if !ok || word != "" || number != nil {
+				return nil, 0, false
+			}
+			if k := strings.IndexByte(token, '.'); k >= 0 && len(token)-k-1 > places {
+				places = len(token) - k - 1
+			}
+			number, hasNumber = tok, true
+			i = j - 1
+			continue
+		}
+		if d, ok := chineseDigits[r]; ok {
+			if number != nil {
+				return nil, 0, false
+			}
+			hasNumber = true
+			if d == 0 {
+				prevUnit = 0
+				continue
+			}
+			number = new(big.Rat).SetInt64(d)
+			continue
+		}
+		unit, ok := chineseUnits[r]
+		if !ok {
+			return nil, 0, false
+		}
+		switch unit {
+		case 1e4, 1e8:
+			if number != nil {
+				section.Add(section, number)
+			}
+			if unit == 1e8 {
+				if wanSeen && section.Sign() != 0 {
+					return nil, 0, false
+				}
+				result.Add(result, section)
+				result.Mul(result, new(big.Rat).SetInt64(unit))
+				wanSeen = false
+			} else {
+				if section.Sign() == 0 && result.Sign() == 0 {
+					return nil, 0, false
+				}
+				result.Add(result, section.Mul(section, new(big.Rat).SetInt64(unit)))
+				wanSeen = true
+			}
+			section = new(big.Rat)
+			lastUnit = 0
+		default:
+			if number == nil {
+				// "十二" is 12 at the start of a section
+				if unit != 10 || lastUnit != 0 || section.Sign() != 0 {
+					return nil, 0, false
+				}
+				number = big.NewRat(1, 1)
+				hasNumber = true
+			}
+			if lastUnit != 0 && unit >= lastUnit {
+				return nil, 0, false
+			}
+			section.Add(section, number.Mul(number, new(big.Rat).SetInt64(unit)))
+			lastUnit = unit
+		}
+		number = nil
+		prevUnit = unit
+	}
+	if !hasNumber {
+		return nil, 0, false
+	}
+	if number != nil {
+		// colloquial "一百二" is 120 and "两万五" is 25000
+		if prevUnit >= 100 {
+			number.Mul(number, new(big.Rat).SetInt64(prevUnit/10))
+		}
+		section.Add(section, number)
+	}
+	return result.Add(result, section), places, true
+}
+
+// normalizeNumber convert a localized number to the canonical form.
+func normalizeNumber(s string, locale *NumberLocale, chinese bool) (string, bool) {
+	if locale != nil {
+		var ok bool
+		if s, ok = locale.normalize(s); !ok {
+			return "", false
+		}
+	}
+	if chinese {
+		s = foldWidth(s)
+		if hasChineseNumeral(s) {
+			return parseChineseNumber(s)
+		}
+	}
+	return s, true
+}
@@ -0,0 +1,32 @@
+package filter
+
+import "testing"
+
+func TestNumberLocale(t *testing.T) {
+	testRun(t, []runCase{
+		{Float64().Locale(NumberLocaleDE), "1.234,56", "1234.56", ""},
+		{Float64().Locale(NumberLocaleDE), "1.23,4", "", "NotFloat64"},
+		{Float64().Locale(NumberLocaleEN), "1,234,567.89", "1.23456789e+06", ""},
+		{Float64().Locale(NumberLocaleFR), "1 234,5", "1234.5", ""},
+		{Float64().Locale(NumberLocaleCH), "1'234.5", "1234.5", ""},
+		{Int().Locale(NumberLocaleZH), "１２３", "123", ""},
+		{Int().Locale(NumberLocaleZH), "1,234,567", "1234567", ""},
+		{Int().Locale(NumberLocaleZH), "12,34", "", "NotInt"},
+		{Int64().Locale(NumberLocaleDE).Units(ByteUnits), "1.500MB", "1500000000", ""},
+		{Decimal().Locale(NumberLocaleDE), "-1.234,50", "-1234.50", ""},
+	})
+}
+
+func TestChineseNumerals(t *testing.T) {
+	testRun(t, []runCase{
+		{Int().ChineseNumerals(), "一百二十", "120", ""},
+		{Int().ChineseNumerals(), "一百二", "120", ""},
+		{Int().ChineseNumerals(), "两千零五", "2005", ""},
+		{Int().ChineseNumerals(), "两万五", "25000", ""},
+		{Int().ChineseNumerals(), "负五", "-5", ""},
+		{Int().ChineseNumerals(), "1.5万", "15000", ""},
+		{Int().ChineseNumerals(), "三点一四", "", "NotInt"},
+		{Float64().ChineseNumerals(), "三点一四", "3.14", ""},
+		{Int(), "一百二十", "", "NotInt"},
+	})
+}
@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"math"
+	"strings"
+)
+
+// strictMode is the strict numeric mode of a filter, the package-wide
+// setting is used by default.
+type strictMode int8
+
+const (
+	strictDefault strictMode = iota
+	strictOn
+	strictOff
+)
+
+var strictNumbers bool
+
+// SetStrictNumbers set the package-wide strict numeric mode of number
+// filters, filters can override it with Strict or Lenient. In strict mode
+// NaN, Inf, hex floats, underscores, a leading "+", leading zeros and
+// surrounding whitespace are rejected.
+func SetStrictNumbers(strict bool) {
+	strictNumbers = strict
+}
+
+// enabled check whether strict mode is enabled.
+func (m strictMode) enabled() bool {
+	switch m {
+	case strictOn:
+		return true
+	case strictOff:
+		return false
+	}
+	return strictNumbers
+}
+
+// check return the error word if strict mode is enabled and s is not a
+// strict number, otherwise return empty string.
+func (m strictMode) check(s string, base int, isFloat bool) string {
+	if !m.enabled() {
+		return ""
+	}
+	if s != strings.Trim(s, " \t\r\n") {
+		return "NumberHasWhitespace"
+	}
+	if strings.HasPrefix(s, "+") {
+		return "NumberHasPlusSign"
+	}
+	body := strings.ToLower(strings.TrimPrefix(s, "-"))
+	if strings.Contains(body, "_") {
+		return "NumberHasUnderscore"
+	}
+	if isFloat {
+		if strings.HasPrefix(body, "0x") {
+			return "NumberIsHexFloat"
+		}
+		if strings.HasPrefix(body, "nan") || strings.HasPrefix(body, "inf") {
+			return "NumberNotFinite"
+		}
+	}
+	if len(body) > 1 && body[0] == '0' && digitInBase(body[1], base) {
+		return "NumberHasLeadingZero"
+	}
+	return ""
+}
+
+// checkRange is like check but check each side of range string s, empty
+// sides are skipped as they take the default values.
+func (m strictMode) checkRange(s string, base int, isFloat bool) string {
+	if !m.enabled() {
+		return ""
+	}
+	if s != strings.Trim(s, " \t\r\n") {
+		return "NumberHasWhitespace"
+	}
+	left, right, _, _, ok := splitRange(s)
+	if !ok {
+		return ""
+	}
+	for _, side := range []string{left, right} {
+		if side == "" {
+			continue
+		}
+		if word := m.check(side, base, isFloat); word != "" {
+			return word
+		}
+	}
+	return ""
+}
+
+// digitInBase check whether lower case c is a digit in base, base 0 is
+// treated as base 10.
+func digitInBase(c byte, base int) bool {
+	if base == 0 {
+		base = 10
+	}
+	var d int
+	switch {
+	case c >= '0' && c <= '9':
+		d = int(c - '0')
+	case c >= 'a' && c <= 'z':
+		d = int(c-'a') + 10
+	default:
+		return false
+	}
+	return d < base
+}
+
+// checkFloat return the error word if strict mode is enabled and v is NaN
+// or infinite, otherwise return empty string.
+func (m strictMode) checkFloat(v float64) string {
+	if m.enabled() && (math.IsNaN(v) || math.IsInf(v, 0)) {
+		return "NumberNotFinite"
+	}
+	return ""
+}
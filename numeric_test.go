@@ -0,0 +1,43 @@
+package filter
+
+import "testing"
+
+func TestStrictNumbers(t *testing.T) {
+	testRun(t, []runCase{
+		{Float64().Strict().Locale(NumberLocaleDE), "0,5", "0.5", ""},
+		{Int64().Strict().Units(ByteUnits), "0B", "0", ""},
+		{Int64().Strict(), "0", "0", ""},
+		{Int64().Strict(), "007", "", "NumberHasLeadingZero"},
+		{Int64().Strict(), "+7", "", "NumberHasPlusSign"},
+		{Int64().Strict(), " 7", "", "NumberHasWhitespace"},
+		{Float64().Strict(), "0.5", "0.5", ""},
+		{Float64().Strict(), "00.5", "", "NumberHasLeadingZero"},
+		{Float64().Strict(), "NaN", "", "NumberNotFinite"},
+		{Decimal().Strict(), "0.25", "0.25", ""},
+		{Decimal().Strict(), "01.5", "", "NumberHasLeadingZero"},
+		{Decimal().Strict(), "+1.5", "", "NumberHasPlusSign"},
+		{Decimal().Lenient(), "+1.5", "1.5", ""},
+		{DecimalSet().Strict(), "1,02", "", "ItemNumberHasLeadingZero"},
+		{DecimalSet().Strict(), "1,0.2", "[1 0.2]", ""},
+		{DecimalRange().Strict(), "(0.5,+2]", "", "NumberHasPlusSign"},
+		{BigInt().Strict(), "1_000", "", "NumberHasUnderscore"},
+		{BigInt().Base(16).Strict(), "0a", "", "NumberHasLeadingZero"},
+		{BigInt().Base(16).Strict(), "a0", "160", ""},
+		{BigIntSet().Strict(), "1,+2", "", "ItemNumberHasPlusSign"},
+		{Timestamp64().Strict(), "017", "", "NumberHasLeadingZero"},
+		{Timestamp64Set().Strict(), "0,17", "[0 17]", ""},
+		{Timestamp64Range().Strict(), "[0,5]", "[0,5]", ""},
+		{Timestamp64Range().Strict(), "[01,5]", "", "NumberHasLeadingZero"},
+		{Timestamp64Range().Strict(), " [0,5]", "", "NumberHasWhitespace"},
+	})
+}
+
+func TestSetStrictNumbers(t *testing.T) {
+	SetStrictNumbers(true)
+	t.Cleanup(func() { SetStrictNumbers(false) })
+	testRun(t, []runCase{
+		{Timestamp64(), "017", "", "NumberHasLeadingZero"},
+		{Timestamp64().Lenient(), "017", "17", ""},
+		{Timestamp64Range().Lenient(), "[01,5]", "[1,5]", ""},
+	})
+}
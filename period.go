@@ -0,0 +1,205 @@
+package filter
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-apibox/types"
+)
+
+// PeriodUnit is the unit of a period.
+type PeriodUnit int
+
+// period units
+const (
+	PeriodWeek PeriodUnit = iota + 1
+	PeriodMonth
+	PeriodQuarter
+	PeriodYear
+)
+
+type PeriodFilter struct {
+	units      []PeriodUnit
+	validators []TimeRangeValidator
+	allowVals  allowList
+}
+
+var errInvalidPeriod = errors.New("invalid period")
+
+var (
+	periodWeekRegexp    = regexp.MustCompile(`^([0-9]{4})-?W([0-9]{2})$`)
+	periodMonthRegexp   = regexp.MustCompile(`^([0-9]{4})-([0-9]{2})$`)
+	periodQuarterRegexp = regexp.MustCompile(`^([0-9]{4})-?Q([1-4])$`)
+	periodYearRegexp    = regexp.MustCompile(`^([0-9]{4})$`)
+)
+
+// Period return a period filter, it accepts ISO week "2024-W05", month
+// "2024-03", quarter "2024-Q1" and year "2024", and output the half-open
+// time range [start, end) of the period as *types.TimeRange.
+func Period() *PeriodFilter {
+	f := new(PeriodFilter)
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *PeriodFilter) Allow(vals ...string) *PeriodFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *PeriodFilter) AllowSpecial(special Special, vals ...string) *PeriodFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *PeriodFilter) AllowSpecialFold(special Special, vals ...string) *PeriodFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Units set the allowed period units, all units are allowed by default.
+func (f *PeriodFilter) Units(units ...PeriodUnit) *PeriodFilter {
+	f.units = units
+	return f
+}
+
+// AddValidator add a custom validator to filter, validators of
+// TimeRangeFilter can be used.
+func (f *PeriodFilter) AddValidator(validator TimeRangeValidator) *PeriodFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// Min valid whether the period does not start before the specified period.
+func (f *PeriodFilter) Min(period string) *PeriodFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
+		start, _, _, err := ParsePeriod(period, timeLoc)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if paramValue.Left.Before(start) {
+			return NewError(ErrorInvalidParam, paramName, "TooEarly")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// Max valid whether the period does not end after the specified period.
+func (f *PeriodFilter) Max(period string) *PeriodFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
+		_, end, _, err := ParsePeriod(period, timeLoc)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if paramValue.Right.After(end) {
+			return NewError(ErrorInvalidParam, paramName, "TooLate")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// Between valid whether the period is in the specified periods.
+func (f *PeriodFilter) Between(minPeriod, maxPeriod string) *PeriodFilter {
+	f.Min(minPeriod)
+	f.Max(maxPeriod)
+	return f
+}
+
+// ParsePeriod parse a period in loc and return its start, exclusive end and
+// unit. Weeks are ISO 8601 weeks which start from monday.
+func ParsePeriod(s string, loc *time.Location) (time.Time, time.Time, PeriodUnit, error) {
+	var m []string
+	if m = periodWeekRegexp.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		week, _ := strconv.Atoi(m[2])
+		// week 1 is the week with January 4th in it
+		jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+		start := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7+7*(week-1))
+		if y, w := start.ISOWeek(); week < 1 || y != year || w != week {
+			return time.Time{}, time.Time{}, 0, errInvalidPeriod
+		}
+		return start, start.AddDate(0, 0, 7), PeriodWeek, nil
+	}
+	if m = periodMonthRegexp.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return time.Time{}, time.Time{}, 0, errInvalidPeriod
+		}
+		start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 1, 0), PeriodMonth, nil
+	}
+	if m = periodQuarterRegexp.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		quarter, _ := strconv.Atoi(m[2])
+		start := time.Date(year, time.Month(3*quarter-2), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 3, 0), PeriodQuarter, nil
+	}
+	if m = periodYearRegexp.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(1, 0, 0), PeriodYear, nil
+	}
+	return time.Time{}, time.Time{}, 0, errInvalidPeriod
+}
+
+// Run make the filter running.
+func (f *PeriodFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	strVal, ok := paramValue.(string)
+	if !ok {
+		return nil, NewError(ErrorInvalidParam, paramName, "NotPeriod")
+	}
+	strVal = strings.Trim(strVal, " \t\r\n")
+	if special, ok := f.allowVals.match(strVal); ok {
+		return special, nil
+	}
+
+	start, end, unit, err := ParsePeriod(strings.ToUpper(strVal), timeLoc)
+	if err != nil {
+		return nil, NewError(ErrorInvalidParam, paramName, "NotPeriod")
+	}
+	if len(f.units) > 0 {
+		allowed := false
+		for _, u := range f.units {
+			if u == unit {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, NewError(ErrorInvalidParam, paramName, "PeriodNotAllowed")
+		}
+	}
+
+	periodRange := &types.TimeRange{
+		Left:        &start,
+		Right:       &end,
+		LeftClosed:  true,
+		RightClosed: false,
+	}
+	for _, validator := range f.validators {
+		if err := validator(paramName, periodRange); err != nil {
+			return nil, err
+		}
+	}
+
+	return periodRange, nil
+}
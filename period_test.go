@@ -0,0 +1,23 @@
+package filter
+
+import "testing"
+
+func TestPeriodFilter(t *testing.T) {
+	testRun(t, []runCase{
+		{Period(), "2024-W01", "[2024-01-01 00:00:00,2024-01-08 00:00:00)", ""},
+		{Period(), "2021W01", "[2021-01-04 00:00:00,2021-01-11 00:00:00)", ""},
+		{Period(), "2020-W53", "[2020-12-28 00:00:00,2021-01-04 00:00:00)", ""},
+		{Period(), "2021-W53", "", "NotPeriod"},
+		{Period(), "2024-W00", "", "NotPeriod"},
+		{Period(), "2024-02", "[2024-02-01 00:00:00,2024-03-01 00:00:00)", ""},
+		{Period(), "2024-13", "", "NotPeriod"},
+		{Period(), "2024-q2", "[2024-04-01 00:00:00,2024-07-01 00:00:00)", ""},
+		{Period(), "2024", "[2024-01-01 00:00:00,2025-01-01 00:00:00)", ""},
+		{Period(), 2024, "", "NotPeriod"},
+		{Period().Units(PeriodMonth), "2024", "", "PeriodNotAllowed"},
+		{Period().Min("2024-01"), "2023-Q4", "", "TooEarly"},
+		{Period().Max("2024-01"), "2024-W05", "", "TooLate"},
+		{Period().Between("2024", "2024"), "2024-06", "[2024-06-01 00:00:00,2024-07-01 00:00:00)", ""},
+		{Period().Max("bad"), "2024", "", "InvalidValidator"},
+	})
+}
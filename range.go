@@ -0,0 +1,35 @@
+package filter
+
+import (
+	"strings"
+)
+
+// splitRange split a range string like "[1,10)" or "1~10" into its left
+// and right parts. Brackets are optional and default to closed, an empty part means
+// the default value of that side should be used.
+func splitRange(s string) (left, right string, leftClosed, rightClosed, ok bool) {
+	leftClosed, rightClosed = true, true
+	if strings.HasPrefix(s, "[") {
+		s = s[1:]
+	} else if strings.HasPrefix(s, "(") {
+		leftClosed = false
+		s = s[1:]
+	}
+	if strings.HasSuffix(s, "]") {
+		s = s[:len(s)-1]
+	} else if strings.HasSuffix(s, ")") {
+		rightClosed = false
+		s = s[:len(s)-1]
+	}
+
+	i := strings.Index(s, "~")
+	if i < 0 {
+		i = strings.Index(s, ",")
+	}
+	if i < 0 {
+		return "", "", false, false, false
+	}
+	left = strings.Trim(s[:i], " \t\r\n")
+	right = strings.Trim(s[i+1:], " \t\r\n")
+	return left, right, leftClosed, rightClosed, true
+}
@@ -0,0 +1,387 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+)
+
+// BigDecimalRange is a range of arbitrary-precision decimals, a nil Left or
+// Right means the range is unbounded on that side.
+type BigDecimalRange struct {
+	Left        *BigDecimal
+	Right       *BigDecimal
+	LeftClosed  bool
+	RightClosed bool
+}
+
+var errInvalidDecimalRange = errors.New("invalid decimal range")
+
+// ParseBigDecimalRange parse a decimal range string like "[1.5,20)", the
+// default values are used when a side is empty.
+func ParseBigDecimalRange(s string, defaultLeft, defaultRight *BigDecimal) (*BigDecimalRange, error) {
+	left, right, leftClosed, rightClosed, ok := splitRange(s)
+	if !ok {
+		return nil, errInvalidDecimalRange
+	}
+
+	r := &BigDecimalRange{defaultLeft, defaultRight, leftClosed, rightClosed}
+	var err error
+	if left != "" {
+		if r.Left, err = ParseBigDecimal(left); err != nil {
+			return nil, err
+		}
+	}
+	if right != "" {
+		if r.Right, err = ParseBigDecimal(right); err != nil {
+			return nil, err
+		}
+	}
+	if r.Left != nil && r.Right != nil && r.Left.Cmp(r.Right) > 0 {
+		return nil, errInvalidDecimalRange
+	}
+	return r, nil
+}
+
+type DecimalRangeFilter struct {
+	strict          strictMode
+	defaultLeftVal  *BigDecimal
+	defaultRightVal *BigDecimal
+	defaultErr      error
+	clamp           bool
+	clampMin        *BigDecimal
+	clampMax        *BigDecimal
+	clampHook       ClampHook
+	clampErr        error
+	validators      []DecimalRangeValidator
+	allowVals       allowList
+}
+
+type DecimalRangeValidator func(paramName string, paramValue *BigDecimalRange) *Error
+
+// DecimalRange return a arbitrary-precision decimal range filter.
+func DecimalRange() *DecimalRangeFilter {
+	f := new(DecimalRangeFilter)
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *DecimalRangeFilter) Allow(vals ...string) *DecimalRangeFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *DecimalRangeFilter) AllowSpecial(special Special, vals ...string) *DecimalRangeFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *DecimalRangeFilter) AllowSpecialFold(special Special, vals ...string) *DecimalRangeFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// LeftDefault set the default left value of range if not specified.
+func (f *DecimalRangeFilter) LeftDefault(val string) *DecimalRangeFilter {
+	var err error
+	if f.defaultLeftVal, err = ParseBigDecimal(val); err != nil {
+		f.defaultErr = err
+	}
+	return f
+}
+
+// RightDefault set the default right value of range if not specified.
+func (f *DecimalRangeFilter) RightDefault(val string) *DecimalRangeFilter {
+	var err error
+	if f.defaultRightVal, err = ParseBigDecimal(val); err != nil {
+		f.defaultErr = err
+	}
+	return f
+}
+
+// Clamp move sides of range out of [min, max] onto the nearest limit instead
+// of failing, a moved side becomes closed and a range left empty is rejected.
+func (f *DecimalRangeFilter) Clamp(min, max string) *DecimalRangeFilter {
+	f.clamp = true
+	var minErr, maxErr error
+	f.clampMin, minErr = ParseBigDecimal(min)
+	f.clampMax, maxErr = ParseBigDecimal(max)
+	if minErr != nil || maxErr != nil {
+		f.clampErr = errInvalidDecimal
+	} else if f.clampMin.Cmp(f.clampMax) > 0 {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *DecimalRangeFilter) OnClamp(hook ClampHook) *DecimalRangeFilter {
+	f.clampHook = hook
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *DecimalRangeFilter) Strict() *DecimalRangeFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *DecimalRangeFilter) Lenient() *DecimalRangeFilter {
+	f.strict = strictOff
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *DecimalRangeFilter) AddValidator(validator DecimalRangeValidator) *DecimalRangeFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// LeftMin valid whether left value of range is not smaller than specified value.
+func (f *DecimalRangeFilter) LeftMin(val string) *DecimalRangeFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue *BigDecimalRange) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Left == nil || paramValue.Left.Cmp(d) < 0 {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftMax valid whether left value of range is not larger than specified value.
+func (f *DecimalRangeFilter) LeftMax(val string) *DecimalRangeFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue *BigDecimalRange) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Left != nil && paramValue.Left.Cmp(d) > 0 {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftBetween valid whether left value of range is in the specified range.
+func (f *DecimalRangeFilter) LeftBetween(min, max string) *DecimalRangeFilter {
+	minVal, minErr := ParseBigDecimal(min)
+	maxVal, maxErr := ParseBigDecimal(max)
+	f.AddValidator(func(paramName string, paramValue *BigDecimalRange) *Error {
+		if minErr != nil || maxErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Left == nil || paramValue.Left.Cmp(minVal) < 0 {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooSmall")
+		}
+		if paramValue.Left.Cmp(maxVal) > 0 {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightMin valid whether right value of range is not smaller than specified value.
+func (f *DecimalRangeFilter) RightMin(val string) *DecimalRangeFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue *BigDecimalRange) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Right != nil && paramValue.Right.Cmp(d) < 0 {
+			return NewError(ErrorInvalidParam, paramName, "RightTooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightMax valid whether right value of range is not larger than specified value.
+func (f *DecimalRangeFilter) RightMax(val string) *DecimalRangeFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue *BigDecimalRange) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Right == nil || paramValue.Right.Cmp(d) > 0 {
+			return NewError(ErrorInvalidParam, paramName, "RightTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightBetween valid whether right value of range is in the specified range.
+func (f *DecimalRangeFilter) RightBetween(min, max string) *DecimalRangeFilter {
+	minVal, minErr := ParseBigDecimal(min)
+	maxVal, maxErr := ParseBigDecimal(max)
+	f.AddValidator(func(paramName string, paramValue *BigDecimalRange) *Error {
+		if minErr != nil || maxErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Right == nil || paramValue.Right.Cmp(maxVal) > 0 {
+			return NewError(ErrorInvalidParam, paramName, "RightTooLarge")
+		}
+		if paramValue.Right.Cmp(minVal) < 0 {
+			return NewError(ErrorInvalidParam, paramName, "RightTooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// MinDistance valid whether the distance of range not smaller than the specified value.
+func (f *DecimalRangeFilter) MinDistance(val string) *DecimalRangeFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue *BigDecimalRange) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Left == nil || paramValue.Right == nil {
+			return nil
+		}
+		if paramValue.Right.Sub(paramValue.Left).Cmp(d) < 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooNear")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxDistance valid whether the distance of range not larger than the specified value.
+func (f *DecimalRangeFilter) MaxDistance(val string) *DecimalRangeFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue *BigDecimalRange) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if paramValue.Left == nil || paramValue.Right == nil {
+			return NewError(ErrorInvalidParam, paramName, "TooFar")
+		}
+		if paramValue.Right.Sub(paramValue.Left).Cmp(d) > 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooFar")
+		}
+		return nil
+	})
+	return f
+}
+
+// clampValue move both sides of r onto the nearest limit of clamp range, an
+// unbounded side is moved onto the limit too.
+func (f *DecimalRangeFilter) clampValue(paramName string, r *BigDecimalRange) (*BigDecimalRange, error) {
+	adjusted := *r
+	if adjusted.Left == nil || adjusted.Left.Cmp(f.clampMin) < 0 {
+		adjusted.Left, adjusted.LeftClosed = f.clampMin, true
+	} else if adjusted.Left.Cmp(f.clampMax) > 0 {
+		adjusted.Left, adjusted.LeftClosed = f.clampMax, true
+	}
+	if adjusted.Right == nil || adjusted.Right.Cmp(f.clampMax) > 0 {
+		adjusted.Right, adjusted.RightClosed = f.clampMax, true
+	} else if adjusted.Right.Cmp(f.clampMin) < 0 {
+		adjusted.Right, adjusted.RightClosed = f.clampMin, true
+	}
+	if adjusted == *r {
+		return r, nil
+	}
+	c := adjusted.Left.Cmp(adjusted.Right)
+	if c > 0 || c == 0 && !(adjusted.LeftClosed && adjusted.RightClosed) {
+		return nil, errClampedRangeEmpty
+	}
+	if f.clampHook != nil {
+		f.clampHook(paramName, r, &adjusted)
+	}
+	return &adjusted, nil
+}
+
+// Run make the filter running.
+func (f *DecimalRangeFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+	if f.defaultErr != nil {
+		return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+	}
+
+	var decRange *BigDecimalRange
+	switch val := paramValue.(type) {
+	case string:
+		raw := val
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.checkRange(raw, 10, true); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		var err error
+		decRange, err = ParseBigDecimalRange(val, f.defaultLeftVal, f.defaultRightVal)
+		if err != nil {
+			goto parse_error
+		}
+	case *BigDecimalRange:
+		decRange = val
+	case BigDecimalRange:
+		decRange = &val
+	default:
+		leftItem, rightItem, ok := coerceRangeItems(val)
+		if !ok {
+			goto parse_error
+		}
+		decRange = &BigDecimalRange{f.defaultLeftVal, f.defaultRightVal, true, true}
+		if leftItem != nil {
+			v, word, ok := coerceDecimal(leftItem)
+			if !ok {
+				goto parse_error
+			}
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, word)
+			}
+			decRange.Left = v
+		}
+		if rightItem != nil {
+			v, word, ok := coerceDecimal(rightItem)
+			if !ok {
+				goto parse_error
+			}
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, word)
+			}
+			decRange.Right = v
+		}
+		if decRange.Left != nil && decRange.Right != nil && decRange.Left.Cmp(decRange.Right) > 0 {
+			goto parse_error
+		}
+	}
+
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		var err error
+		if decRange, err = f.clampValue(paramName, decRange); err != nil {
+			return nil, NewError(ErrorInvalidParam, paramName, "RangeEmptyAfterClamp")
+		}
+	}
+	for _, validator := range f.validators {
+		if err := validator(paramName, decRange); err != nil {
+			return nil, err
+		}
+	}
+
+	return decRange, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotDecimalRange")
+}
@@ -7,10 +7,16 @@ import (
 )
 
 type IntRangeFilter struct {
+	strict          strictMode
 	defaultLeftVal  int
 	defaultRightVal int
+	clamp           bool
+	clampMin        int
+	clampMax        int
+	clampErr        error
+	clampHook       ClampHook
 	validators      []IntRangeValidator
-	allowVals       []string
+	allowVals       allowList
 }
 
 type IntRangeValidator func(paramName string, paramValue *types.IntRange) *Error
@@ -25,9 +31,24 @@ func IntRange() *IntRangeFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *IntRangeFilter) Allow(vals ...string) *IntRangeFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *IntRangeFilter) AllowSpecial(special Special, vals ...string) *IntRangeFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *IntRangeFilter) AllowSpecialFold(special Special, vals ...string) *IntRangeFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -43,6 +64,38 @@ func (f *IntRangeFilter) RightDefault(val int) *IntRangeFilter {
 	return f
 }
 
+// Clamp move sides of range out of [min, max] onto the nearest limit instead
+// of failing, a moved side becomes closed and a range left empty is rejected.
+func (f *IntRangeFilter) Clamp(min, max int) *IntRangeFilter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *IntRangeFilter) OnClamp(hook ClampHook) *IntRangeFilter {
+	f.clampHook = hook
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *IntRangeFilter) Strict() *IntRangeFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *IntRangeFilter) Lenient() *IntRangeFilter {
+	f.strict = strictOff
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *IntRangeFilter) AddValidator(validator IntRangeValidator) *IntRangeFilter {
 	f.validators = append(f.validators, validator)
@@ -315,6 +368,31 @@ func (f *IntRangeFilter) MaxDistance(val uint) *IntRangeFilter {
 	return f
 }
 
+// clampValue move both sides of r onto the nearest limit of clamp range.
+func (f *IntRangeFilter) clampValue(paramName string, r *types.IntRange) (*types.IntRange, error) {
+	adjusted := *r
+	if adjusted.Left < f.clampMin {
+		adjusted.Left, adjusted.LeftClosed = f.clampMin, true
+	} else if adjusted.Left > f.clampMax {
+		adjusted.Left, adjusted.LeftClosed = f.clampMax, true
+	}
+	if adjusted.Right < f.clampMin {
+		adjusted.Right, adjusted.RightClosed = f.clampMin, true
+	} else if adjusted.Right > f.clampMax {
+		adjusted.Right, adjusted.RightClosed = f.clampMax, true
+	}
+	if adjusted == *r {
+		return r, nil
+	}
+	if adjusted.Left > adjusted.Right || adjusted.Left == adjusted.Right && !(adjusted.LeftClosed && adjusted.RightClosed) {
+		return nil, errClampedRangeEmpty
+	}
+	if f.clampHook != nil {
+		f.clampHook(paramName, r, &adjusted)
+	}
+	return &adjusted, nil
+}
+
 // Run make the filter running.
 func (f *IntRangeFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -324,11 +402,13 @@ func (f *IntRangeFilter) Run(paramName string, paramValue interface{}) (interfac
 	var intRange *types.IntRange
 	switch val := paramValue.(type) {
 	case string:
+		raw := val
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.checkRange(raw, 10, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
 		}
 		var err error
 		intRange, err = types.ParseIntRange(val, f.defaultLeftVal, f.defaultRightVal)
@@ -340,9 +420,25 @@ func (f *IntRangeFilter) Run(paramName string, paramValue interface{}) (interfac
 	case types.IntRange:
 		intRange = &val
 	default:
-		goto parse_error
+		left, right, word, ok := coerceInt64Range(val, int64(types.MinInt), int64(types.MaxInt), int64(f.defaultLeftVal), int64(f.defaultRightVal))
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		intRange = &types.IntRange{Left: int(left), Right: int(right), LeftClosed: true, RightClosed: true}
 	}
 
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		var err error
+		if intRange, err = f.clampValue(paramName, intRange); err != nil {
+			return nil, NewError(ErrorInvalidParam, paramName, "RangeEmptyAfterClamp")
+		}
+	}
 	for _, validator := range f.validators {
 		if err := validator(paramName, intRange); err != nil {
 			return nil, err
@@ -8,10 +8,16 @@ import (
 )
 
 type Int32RangeFilter struct {
+	strict          strictMode
 	defaultLeftVal  int32
 	defaultRightVal int32
+	clamp           bool
+	clampMin        int32
+	clampMax        int32
+	clampErr        error
+	clampHook       ClampHook
 	validators      []Int32RangeValidator
-	allowVals       []string
+	allowVals       allowList
 }
 
 type Int32RangeValidator func(paramName string, paramValue *types.Int32Range) *Error
@@ -26,9 +32,24 @@ func Int32Range() *Int32RangeFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *Int32RangeFilter) Allow(vals ...string) *Int32RangeFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *Int32RangeFilter) AllowSpecial(special Special, vals ...string) *Int32RangeFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *Int32RangeFilter) AllowSpecialFold(special Special, vals ...string) *Int32RangeFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -44,6 +65,38 @@ func (f *Int32RangeFilter) RightDefault(val int32) *Int32RangeFilter {
 	return f
 }
 
+// Clamp move sides of range out of [min, max] onto the nearest limit instead
+// of failing, a moved side becomes closed and a range left empty is rejected.
+func (f *Int32RangeFilter) Clamp(min, max int32) *Int32RangeFilter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *Int32RangeFilter) OnClamp(hook ClampHook) *Int32RangeFilter {
+	f.clampHook = hook
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *Int32RangeFilter) Strict() *Int32RangeFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *Int32RangeFilter) Lenient() *Int32RangeFilter {
+	f.strict = strictOff
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *Int32RangeFilter) AddValidator(validator Int32RangeValidator) *Int32RangeFilter {
 	f.validators = append(f.validators, validator)
@@ -316,6 +369,31 @@ func (f *Int32RangeFilter) MaxDistance(val uint) *Int32RangeFilter {
 	return f
 }
 
+// clampValue move both sides of r onto the nearest limit of clamp range.
+func (f *Int32RangeFilter) clampValue(paramName string, r *types.Int32Range) (*types.Int32Range, error) {
+	adjusted := *r
+	if adjusted.Left < f.clampMin {
+		adjusted.Left, adjusted.LeftClosed = f.clampMin, true
+	} else if adjusted.Left > f.clampMax {
+		adjusted.Left, adjusted.LeftClosed = f.clampMax, true
+	}
+	if adjusted.Right < f.clampMin {
+		adjusted.Right, adjusted.RightClosed = f.clampMin, true
+	} else if adjusted.Right > f.clampMax {
+		adjusted.Right, adjusted.RightClosed = f.clampMax, true
+	}
+	if adjusted == *r {
+		return r, nil
+	}
+	if adjusted.Left > adjusted.Right || adjusted.Left == adjusted.Right && !(adjusted.LeftClosed && adjusted.RightClosed) {
+		return nil, errClampedRangeEmpty
+	}
+	if f.clampHook != nil {
+		f.clampHook(paramName, r, &adjusted)
+	}
+	return &adjusted, nil
+}
+
 // Run make the filter running.
 func (f *Int32RangeFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -325,11 +403,13 @@ func (f *Int32RangeFilter) Run(paramName string, paramValue interface{}) (interf
 	var int32Range *types.Int32Range
 	switch val := paramValue.(type) {
 	case string:
+		raw := val
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.checkRange(raw, 10, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
 		}
 		var err error
 		int32Range, err = types.ParseInt32Range(val, f.defaultLeftVal, f.defaultRightVal)
@@ -341,9 +421,25 @@ func (f *Int32RangeFilter) Run(paramName string, paramValue interface{}) (interf
 	case types.Int32Range:
 		int32Range = &val
 	default:
-		goto parse_error
+		left, right, word, ok := coerceInt64Range(val, math.MinInt32, math.MaxInt32, int64(f.defaultLeftVal), int64(f.defaultRightVal))
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		int32Range = &types.Int32Range{Left: int32(left), Right: int32(right), LeftClosed: true, RightClosed: true}
 	}
 
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		var err error
+		if int32Range, err = f.clampValue(paramName, int32Range); err != nil {
+			return nil, NewError(ErrorInvalidParam, paramName, "RangeEmptyAfterClamp")
+		}
+	}
 	for _, validator := range f.validators {
 		if err := validator(paramName, int32Range); err != nil {
 			return nil, err
@@ -9,10 +9,16 @@ import (
 )
 
 type Int64RangeFilter struct {
+	strict          strictMode
 	defaultLeftVal  int64
 	defaultRightVal int64
+	clamp           bool
+	clampMin        int64
+	clampMax        int64
+	clampErr        error
+	clampHook       ClampHook
 	validators      []Int64RangeValidator
-	allowVals       []string
+	allowVals       allowList
 }
 
 type Int64RangeValidator func(paramName string, paramValue *types.Int64Range) *Error
@@ -27,9 +33,24 @@ func Int64Range() *Int64RangeFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *Int64RangeFilter) Allow(vals ...string) *Int64RangeFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *Int64RangeFilter) AllowSpecial(special Special, vals ...string) *Int64RangeFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *Int64RangeFilter) AllowSpecialFold(special Special, vals ...string) *Int64RangeFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -45,6 +66,38 @@ func (f *Int64RangeFilter) RightDefault(val int64) *Int64RangeFilter {
 	return f
 }
 
+// Clamp move sides of range out of [min, max] onto the nearest limit instead
+// of failing, a moved side becomes closed and a range left empty is rejected.
+func (f *Int64RangeFilter) Clamp(min, max int64) *Int64RangeFilter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *Int64RangeFilter) OnClamp(hook ClampHook) *Int64RangeFilter {
+	f.clampHook = hook
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *Int64RangeFilter) Strict() *Int64RangeFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *Int64RangeFilter) Lenient() *Int64RangeFilter {
+	f.strict = strictOff
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *Int64RangeFilter) AddValidator(validator Int64RangeValidator) *Int64RangeFilter {
 	f.validators = append(f.validators, validator)
@@ -327,6 +380,31 @@ func (f *Int64RangeFilter) MaxDistance(val uint64) *Int64RangeFilter {
 	return f
 }
 
+// clampValue move both sides of r onto the nearest limit of clamp range.
+func (f *Int64RangeFilter) clampValue(paramName string, r *types.Int64Range) (*types.Int64Range, error) {
+	adjusted := *r
+	if adjusted.Left < f.clampMin {
+		adjusted.Left, adjusted.LeftClosed = f.clampMin, true
+	} else if adjusted.Left > f.clampMax {
+		adjusted.Left, adjusted.LeftClosed = f.clampMax, true
+	}
+	if adjusted.Right < f.clampMin {
+		adjusted.Right, adjusted.RightClosed = f.clampMin, true
+	} else if adjusted.Right > f.clampMax {
+		adjusted.Right, adjusted.RightClosed = f.clampMax, true
+	}
+	if adjusted == *r {
+		return r, nil
+	}
+	if adjusted.Left > adjusted.Right || adjusted.Left == adjusted.Right && !(adjusted.LeftClosed && adjusted.RightClosed) {
+		return nil, errClampedRangeEmpty
+	}
+	if f.clampHook != nil {
+		f.clampHook(paramName, r, &adjusted)
+	}
+	return &adjusted, nil
+}
+
 // Run make the filter running.
 func (f *Int64RangeFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -336,11 +414,13 @@ func (f *Int64RangeFilter) Run(paramName string, paramValue interface{}) (interf
 	var int64Range *types.Int64Range
 	switch val := paramValue.(type) {
 	case string:
+		raw := val
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.checkRange(raw, 10, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
 		}
 		var err error
 		int64Range, err = types.ParseInt64Range(val, f.defaultLeftVal, f.defaultRightVal)
@@ -352,9 +432,25 @@ func (f *Int64RangeFilter) Run(paramName string, paramValue interface{}) (interf
 	case types.Int64Range:
 		int64Range = &val
 	default:
-		goto parse_error
+		left, right, word, ok := coerceInt64Range(val, math.MinInt64, math.MaxInt64, f.defaultLeftVal, f.defaultRightVal)
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		int64Range = &types.Int64Range{Left: left, Right: right, LeftClosed: true, RightClosed: true}
 	}
 
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		var err error
+		if int64Range, err = f.clampValue(paramName, int64Range); err != nil {
+			return nil, NewError(ErrorInvalidParam, paramName, "RangeEmptyAfterClamp")
+		}
+	}
 	for _, validator := range f.validators {
 		if err := validator(paramName, int64Range); err != nil {
 			return nil, err
@@ -8,54 +8,144 @@ import (
 )
 
 type TimeRangeFilter struct {
-	layout          string
-	delimiter       string
-	defaultLeftVal  string
-	defaultRightVal string
-	validators      []TimeRangeValidator
-	allowVals       []string
+	layouts          []string
+	delimiter        string
+	defaultLeftVal   string
+	defaultRightVal  string
+	defaultLeftFunc  func() time.Time
+	defaultRightFunc func() time.Time
+	granularity      TimeGranularity
+	halfOpen         bool
+	clamp            bool
+	clampStart       string
+	clampEnd         string
+	clampHook        ClampHook
+	validators       []TimeRangeValidator
+	allowVals        allowList
 }
 
 type TimeRangeValidator func(paramName string, paramValue *types.TimeRange) *Error
 
 // TimeRange return a timestamp range filter.
+// Time bounds of validators can also be relative time expressions like
+// "now-90d", see ParseRelativeTime.
 func TimeRange() *TimeRangeFilter {
 	f := new(TimeRangeFilter)
-	f.layout = "2006-01-02"
+	f.layouts = []string{LayoutDate}
 
-	f.defaultLeftVal = time.Unix(0, 0).Format(f.layout)
-	f.defaultRightVal = time.Now().Format(f.layout)
+	f.defaultLeftVal = time.Unix(0, 0).Format(LayoutDate)
+	f.defaultRightVal = "today"
 
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *TimeRangeFilter) Allow(vals ...string) *TimeRangeFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *TimeRangeFilter) AllowSpecial(special Special, vals ...string) *TimeRangeFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *TimeRangeFilter) AllowSpecialFold(special Special, vals ...string) *TimeRangeFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
 // HasTime set the layout to include time.
 func (f *TimeRangeFilter) HasTime() *TimeRangeFilter {
-	f.layout = "2006-01-02 15:04:05"
+	f.layouts = []string{LayoutDateTime}
 	return f
 }
 
 // Layout set the time layout.
 func (f *TimeRangeFilter) Layout(layout string) *TimeRangeFilter {
-	f.layout = layout
+	f.layouts = []string{layout}
+	return f
+}
+
+// Layouts set the accepted time layouts, each side of range is parsed with
+// the layouts in order and the first success wins. Preset layouts such as
+// LayoutRFC3339 and LayoutUnix can be used. An explicit zone offset in value
+// wins over the default location.
+func (f *TimeRangeFilter) Layouts(layouts ...string) *TimeRangeFilter {
+	f.layouts = layouts
 	return f
 }
 
 // LeftDefault set the default left value of range if not specified.
+// It can be a relative time expression like "today-30d", which is evaluated
+// on each run.
 func (f *TimeRangeFilter) LeftDefault(tm string) *TimeRangeFilter {
 	f.defaultLeftVal = tm
+	f.defaultLeftFunc = nil
 	return f
 }
 
 // RightDefault set the default right value of range if not specified.
+// It can be a relative time expression like "now", which is evaluated on
+// each run.
 func (f *TimeRangeFilter) RightDefault(tm string) *TimeRangeFilter {
 	f.defaultRightVal = tm
+	f.defaultRightFunc = nil
+	return f
+}
+
+// LeftDefaultFunc set the provider of default left value of range, it is
+// called on each run if left value is not specified.
+func (f *TimeRangeFilter) LeftDefaultFunc(provider func() time.Time) *TimeRangeFilter {
+	f.defaultLeftFunc = provider
+	return f
+}
+
+// RightDefaultFunc set the provider of default right value of range, it is
+// called on each run if right value is not specified.
+func (f *TimeRangeFilter) RightDefaultFunc(provider func() time.Time) *TimeRangeFilter {
+	f.defaultRightFunc = provider
+	return f
+}
+
+// Granularity set the unit of range values. Both sides are truncated to the
+// start of their unit, open/closed adjustments of validators step by the unit,
+// and a closed right side is expanded to the end of its unit in output, eg:
+// "2024-01-01~2024-01-31" with GranularityDay ends at the last nanosecond of
+// Jan 31.
+func (f *TimeRangeFilter) Granularity(granularity TimeGranularity) *TimeRangeFilter {
+	f.granularity = granularity
+	return f
+}
+
+// HalfOpen output the range in canonical half-open form [start, end), which
+// is safe to use in SQL as "start <= t AND t < end". A closed right side is
+// moved by one unit of granularity, which defaults to the finest unit of the
+// layouts, eg: one day for LayoutDate.
+func (f *TimeRangeFilter) HalfOpen() *TimeRangeFilter {
+	f.halfOpen = true
+	return f
+}
+
+// Clamp move sides of range earlier than startTime or later than endTime onto
+// the nearest limit instead of failing, a moved side becomes closed and a
+// range left empty is rejected.
+func (f *TimeRangeFilter) Clamp(startTime, endTime string) *TimeRangeFilter {
+	f.clamp = true
+	f.clampStart = startTime
+	f.clampEnd = endTime
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped, values are in the output form of the filter.
+func (f *TimeRangeFilter) OnClamp(hook ClampHook) *TimeRangeFilter {
+	f.clampHook = hook
 	return f
 }
 
@@ -68,13 +158,13 @@ func (f *TimeRangeFilter) AddValidator(validator TimeRangeValidator) *TimeRangeF
 // LeftStartFrom valid whether left value of range is start from specified time.
 func (f *TimeRangeFilter) LeftStartFrom(tm string) *TimeRangeFilter {
 	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := f.parseBound(tm)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
 		if !paramValue.LeftClosed {
-			t = t.Add(time.Duration(-1) * time.Second)
+			t = f.stepUnit().step(t, -1)
 		}
 		if paramValue.Left.Before(t) {
 			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
@@ -88,13 +178,13 @@ func (f *TimeRangeFilter) LeftStartFrom(tm string) *TimeRangeFilter {
 // LeftEndTo valid whether left value of range is end to specified time.
 func (f *TimeRangeFilter) LeftEndTo(tm string) *TimeRangeFilter {
 	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := f.parseBound(tm)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
 		if !paramValue.LeftClosed {
-			t = t.Add(time.Duration(-1) * time.Second)
+			t = f.stepUnit().step(t, -1)
 		}
 		if paramValue.Left.After(t) {
 			return NewError(ErrorInvalidParam, paramName, "LeftTooLate")
@@ -108,13 +198,13 @@ func (f *TimeRangeFilter) LeftEndTo(tm string) *TimeRangeFilter {
 // LeftAfter valid whether left value of range is after specified time.
 func (f *TimeRangeFilter) LeftAfter(tm string) *TimeRangeFilter {
 	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := f.parseBound(tm)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
 		if !paramValue.LeftClosed {
-			t = t.Add(time.Duration(-1) * time.Second)
+			t = f.stepUnit().step(t, -1)
 		}
 		if !paramValue.Left.After(t) {
 			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
@@ -128,13 +218,13 @@ func (f *TimeRangeFilter) LeftAfter(tm string) *TimeRangeFilter {
 // LeftBefore valid whether left value of range is before specified time.
 func (f *TimeRangeFilter) LeftBefore(tm string) *TimeRangeFilter {
 	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := f.parseBound(tm)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
 		if !paramValue.LeftClosed {
-			t = t.Add(time.Duration(-1) * time.Second)
+			t = f.stepUnit().step(t, -1)
 		}
 		if !paramValue.Left.Before(t) {
 			return NewError(ErrorInvalidParam, paramName, "LeftTooLate")
@@ -148,13 +238,13 @@ func (f *TimeRangeFilter) LeftBefore(tm string) *TimeRangeFilter {
 // LeftEqual valid whether left value of range is equal to specified time.
 func (f *TimeRangeFilter) LeftEqual(tm string) *TimeRangeFilter {
 	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := f.parseBound(tm)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
 		if !paramValue.LeftClosed {
-			t = t.Add(time.Duration(-1) * time.Second)
+			t = f.stepUnit().step(t, -1)
 		}
 		if paramValue.Left.Before(t) {
 			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
@@ -171,18 +261,18 @@ func (f *TimeRangeFilter) LeftEqual(tm string) *TimeRangeFilter {
 // LeftBetween valid whether left value of range is in the specified range.
 func (f *TimeRangeFilter) LeftBetween(startTime, endTime string) *TimeRangeFilter {
 	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
-		startTime, err := time.ParseInLocation(f.layout, startTime, timeLoc)
+		startTime, err := f.parseBound(startTime)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
-		endTime, err := time.ParseInLocation(f.layout, endTime, timeLoc)
+		endTime, err := f.parseBound(endTime)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
 		if !paramValue.LeftClosed {
-			startTime = startTime.Add(time.Duration(-1) * time.Second)
-			endTime = endTime.Add(time.Duration(-1) * time.Second)
+			startTime = f.stepUnit().step(startTime, -1)
+			endTime = f.stepUnit().step(endTime, -1)
 		}
 
 		if paramValue.Left.Before(startTime) {
@@ -200,13 +290,13 @@ func (f *TimeRangeFilter) LeftBetween(startTime, endTime string) *TimeRangeFilte
 // RightStartFrom valid whether right value of range is start from specified time.
 func (f *TimeRangeFilter) RightStartFrom(tm string) *TimeRangeFilter {
 	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := f.parseBound(tm)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
 		if !paramValue.RightClosed {
-			t = t.Add(time.Duration(1) * time.Second)
+			t = f.stepUnit().step(t, 1)
 		}
 		if paramValue.Right.Before(t) {
 			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
@@ -220,13 +310,13 @@ func (f *TimeRangeFilter) RightStartFrom(tm string) *TimeRangeFilter {
 // RightEndTo valid whether right value of range is end to specified time.
 func (f *TimeRangeFilter) RightEndTo(tm string) *TimeRangeFilter {
 	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := f.parseBound(tm)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
 		if !paramValue.RightClosed {
-			t = t.Add(time.Duration(1) * time.Second)
+			t = f.stepUnit().step(t, 1)
 		}
 		if paramValue.Right.After(t) {
 			return NewError(ErrorInvalidParam, paramName, "RightTooLate")
@@ -240,13 +330,13 @@ func (f *TimeRangeFilter) RightEndTo(tm string) *TimeRangeFilter {
 // RightAfter valid whether right value of range is after specified time.
 func (f *TimeRangeFilter) RightAfter(tm string) *TimeRangeFilter {
 	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := f.parseBound(tm)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
 		if !paramValue.RightClosed {
-			t = t.Add(time.Duration(1) * time.Second)
+			t = f.stepUnit().step(t, 1)
 		}
 		if !paramValue.Right.After(t) {
 			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
@@ -260,13 +350,13 @@ func (f *TimeRangeFilter) RightAfter(tm string) *TimeRangeFilter {
 // RightBefore valid whether right value of range is before specified time.
 func (f *TimeRangeFilter) RightBefore(tm string) *TimeRangeFilter {
 	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := f.parseBound(tm)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
 		if !paramValue.RightClosed {
-			t = t.Add(time.Duration(1) * time.Second)
+			t = f.stepUnit().step(t, 1)
 		}
 		if !paramValue.Right.Before(t) {
 			return NewError(ErrorInvalidParam, paramName, "RightTooLate")
@@ -280,13 +370,13 @@ func (f *TimeRangeFilter) RightBefore(tm string) *TimeRangeFilter {
 // RightEqual valid whether right value of range is equal to specified time.
 func (f *TimeRangeFilter) RightEqual(tm string) *TimeRangeFilter {
 	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := f.parseBound(tm)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
 		if !paramValue.RightClosed {
-			t = t.Add(time.Duration(1) * time.Second)
+			t = f.stepUnit().step(t, 1)
 		}
 		if paramValue.Right.Before(t) {
 			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
@@ -303,18 +393,18 @@ func (f *TimeRangeFilter) RightEqual(tm string) *TimeRangeFilter {
 // RightBetween valid whether right value of range is in the specified range.
 func (f *TimeRangeFilter) RightBetween(startTime, endTime string) *TimeRangeFilter {
 	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
-		startTime, err := time.ParseInLocation(f.layout, startTime, timeLoc)
+		startTime, err := f.parseBound(startTime)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
-		endTime, err := time.ParseInLocation(f.layout, endTime, timeLoc)
+		endTime, err := f.parseBound(endTime)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
 		if !paramValue.RightClosed {
-			startTime = startTime.Add(time.Duration(1) * time.Second)
-			endTime = endTime.Add(time.Duration(1) * time.Second)
+			startTime = f.stepUnit().step(startTime, 1)
+			endTime = f.stepUnit().step(endTime, 1)
 		}
 		if paramValue.Right.Before(startTime) {
 			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
@@ -340,14 +430,7 @@ func (f *TimeRangeFilter) MinDistance(duration string) *TimeRangeFilter {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
-		dist := paramValue.Right.Sub(*paramValue.Left)
-		d := dist.Seconds()
-		if !paramValue.LeftClosed {
-			d = d - 1
-		}
-		if !paramValue.RightClosed {
-			d = d - 1
-		}
+		d := f.distance(paramValue).Seconds()
 
 		if d < valDuration.Seconds() {
 			return NewError(ErrorInvalidParam, paramName, "TooNear")
@@ -370,16 +453,133 @@ func (f *TimeRangeFilter) MaxDistance(duration string) *TimeRangeFilter {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
 
-		dist := paramValue.Right.Sub(*paramValue.Left)
-		d := dist.Seconds()
-		if !paramValue.LeftClosed {
-			d = d - 1
+		d := f.distance(paramValue).Seconds()
+
+		if d > valDuration.Seconds() {
+			return NewError(ErrorInvalidParam, paramName, "TooFar")
 		}
-		if !paramValue.RightClosed {
-			d = d - 1
+
+		return nil
+	})
+	return f
+}
+
+// MinCalendarDistance valid whether the distance of range not smaller than the
+// specified calendar distance, eg: "1d", "2w", "3M", "1Q", "1y" or "1y6M".
+func (f *TimeRangeFilter) MinCalendarDistance(expr string) *TimeRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
+		left, right := f.effectiveEnds(paramValue)
+		c, err := compareCalendarDistance(left, right, expr, timeLoc)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if c < 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooNear")
 		}
 
-		if d > valDuration.Seconds() {
+		return nil
+	})
+	return f
+}
+
+// MaxCalendarDistance valid whether the distance of range not larger than the
+// specified calendar distance, eg: "1d", "2w", "3M", "1Q", "1y" or "1y6M".
+func (f *TimeRangeFilter) MaxCalendarDistance(expr string) *TimeRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
+		left, right := f.effectiveEnds(paramValue)
+		c, err := compareCalendarDistance(left, right, expr, timeLoc)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if c > 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooFar")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// effectiveEnds return both ends of range excluding the open ends.
+func (f *TimeRangeFilter) effectiveEnds(r *types.TimeRange) (time.Time, time.Time) {
+	left, right := *r.Left, *r.Right
+	if !r.LeftClosed {
+		left = f.stepUnit().step(left, 1)
+	}
+	if !r.RightClosed {
+		right = f.stepUnit().step(right, -1)
+	}
+	return left, right
+}
+
+// Workday valid whether both values of range are working days in the calendar.
+func (f *TimeRangeFilter) Workday(cal *Calendar) *TimeRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
+		left, right := f.effectiveEnds(paramValue)
+		if !cal.IsWorkday(left) {
+			return NewError(ErrorInvalidParam, paramName, "LeftNotWorkday")
+		}
+		if !cal.IsWorkday(right) {
+			return NewError(ErrorInvalidParam, paramName, "RightNotWorkday")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// NotHoliday valid whether both values of range are not holidays in the calendar.
+func (f *TimeRangeFilter) NotHoliday(cal *Calendar) *TimeRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
+		left, right := f.effectiveEnds(paramValue)
+		if cal.IsHoliday(left) {
+			return NewError(ErrorInvalidParam, paramName, "LeftHoliday")
+		}
+		if cal.IsHoliday(right) {
+			return NewError(ErrorInvalidParam, paramName, "RightHoliday")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// InWorkingHours valid whether both values of range are in working hours of working days in the calendar.
+func (f *TimeRangeFilter) InWorkingHours(cal *Calendar) *TimeRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
+		left, right := f.effectiveEnds(paramValue)
+		if !cal.InWorkingHours(left) {
+			return NewError(ErrorInvalidParam, paramName, "LeftNotWorkingHours")
+		}
+		if !cal.InWorkingHours(right) {
+			return NewError(ErrorInvalidParam, paramName, "RightNotWorkingHours")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// MinBusinessDays valid whether the range spans at least the specified number
+// of working days in the calendar, both end dates are counted.
+func (f *TimeRangeFilter) MinBusinessDays(cal *Calendar, days int) *TimeRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
+		left, right := f.effectiveEnds(paramValue)
+		if cal.BusinessDays(left, right) < days {
+			return NewError(ErrorInvalidParam, paramName, "TooNear")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// MaxBusinessDays valid whether the range spans at most the specified number
+// of working days in the calendar, both end dates are counted.
+func (f *TimeRangeFilter) MaxBusinessDays(cal *Calendar, days int) *TimeRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimeRange) *Error {
+		left, right := f.effectiveEnds(paramValue)
+		if cal.BusinessDays(left, right) > days {
 			return NewError(ErrorInvalidParam, paramName, "TooFar")
 		}
 
@@ -388,6 +588,161 @@ func (f *TimeRangeFilter) MaxDistance(duration string) *TimeRangeFilter {
 	return f
 }
 
+// distance return the distance of range excluding the open ends.
+func (f *TimeRangeFilter) distance(r *types.TimeRange) time.Duration {
+	left, right := f.effectiveEnds(r)
+	return right.Sub(left)
+}
+
+// stepUnit return the unit of open/closed adjustments, the granularity if set
+// or the finest unit of the layouts.
+func (f *TimeRangeFilter) stepUnit() TimeGranularity {
+	if f.granularity != 0 {
+		return f.granularity
+	}
+	return layoutGranularity(f.layouts)
+}
+
+// parseBound parse a time bound of validator and truncate it to granularity.
+func (f *TimeRangeFilter) parseBound(tm string) (time.Time, error) {
+	t, err := parseTimeBound(f.layouts, tm, timeLoc)
+	if err != nil {
+		return t, err
+	}
+	return f.granularity.truncate(t), nil
+}
+
+// normalize truncate both sides of range to granularity.
+func (f *TimeRangeFilter) normalize(r *types.TimeRange) *types.TimeRange {
+	if f.granularity == 0 {
+		return r
+	}
+	left := f.granularity.truncate(*r.Left)
+	right := f.granularity.truncate(*r.Right)
+	return &types.TimeRange{
+		Left:        &left,
+		Right:       &right,
+		LeftClosed:  r.LeftClosed,
+		RightClosed: r.RightClosed,
+	}
+}
+
+// output convert the validated range to its output form.
+func (f *TimeRangeFilter) output(r *types.TimeRange) *types.TimeRange {
+	if f.halfOpen {
+		left, right := *r.Left, *r.Right
+		if !r.LeftClosed {
+			left = f.stepUnit().step(left, 1)
+		}
+		if r.RightClosed {
+			right = f.stepUnit().step(right, 1)
+		}
+		return &types.TimeRange{
+			Left:        &left,
+			Right:       &right,
+			LeftClosed:  true,
+			RightClosed: false,
+		}
+	}
+	if f.granularity != 0 && r.RightClosed {
+		right := f.stepUnit().step(*r.Right, 1).Add(-time.Nanosecond)
+		return &types.TimeRange{
+			Left:        r.Left,
+			Right:       &right,
+			LeftClosed:  r.LeftClosed,
+			RightClosed: r.RightClosed,
+		}
+	}
+	return r
+}
+
+// parseRange parse a time range string like "[2016-01-01,2016-02-01)", an
+// empty side takes the default value.
+func (f *TimeRangeFilter) parseRange(s string) (*types.TimeRange, error) {
+	left, right, leftClosed, rightClosed, ok := splitRange(s)
+	if !ok {
+		return nil, errInvalidTime
+	}
+
+	var leftTime, rightTime time.Time
+	var err error
+	if left != "" {
+		leftTime, err = parseTime(f.layouts, left, timeLoc)
+	} else {
+		leftTime, err = f.defaultTime(f.defaultLeftFunc, f.defaultLeftVal)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if right != "" {
+		rightTime, err = parseTime(f.layouts, right, timeLoc)
+	} else {
+		rightTime, err = f.defaultTime(f.defaultRightFunc, f.defaultRightVal)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if leftTime.After(rightTime) {
+		return nil, errInvalidTime
+	}
+
+	return &types.TimeRange{
+		Left:        &leftTime,
+		Right:       &rightTime,
+		LeftClosed:  leftClosed,
+		RightClosed: rightClosed,
+	}, nil
+}
+
+// defaultTime return the default value of range side, which is evaluated at
+// run time. Default values may be given in any of the filter's layouts, as a
+// date, or as a relative time expression.
+func (f *TimeRangeFilter) defaultTime(provider func() time.Time, val string) (time.Time, error) {
+	if provider != nil {
+		return provider(), nil
+	}
+	layouts := append(f.layouts[:len(f.layouts):len(f.layouts)], LayoutDate)
+	return parseTimeBound(layouts, val, timeLoc)
+}
+
+// clampValue move both sides of r onto the nearest limit of clamp range.
+func (f *TimeRangeFilter) clampValue(paramName string, r *types.TimeRange) (*types.TimeRange, error) {
+	start, err := f.parseBound(f.clampStart)
+	if err != nil {
+		return nil, err
+	}
+	end, err := f.parseBound(f.clampEnd)
+	if err != nil {
+		return nil, err
+	}
+	if start.After(end) {
+		return nil, errInvalidClamp
+	}
+
+	adjusted := *r
+	if adjusted.Left.Before(start) {
+		adjusted.Left, adjusted.LeftClosed = &start, true
+	} else if adjusted.Left.After(end) {
+		adjusted.Left, adjusted.LeftClosed = &end, true
+	}
+	if adjusted.Right.Before(start) {
+		adjusted.Right, adjusted.RightClosed = &start, true
+	} else if adjusted.Right.After(end) {
+		adjusted.Right, adjusted.RightClosed = &end, true
+	}
+	if adjusted == *r {
+		return r, nil
+	}
+	if adjusted.Left.After(*adjusted.Right) ||
+		adjusted.Left.Equal(*adjusted.Right) && !(adjusted.LeftClosed && adjusted.RightClosed) {
+		return nil, errClampedRangeEmpty
+	}
+	if f.clampHook != nil {
+		f.clampHook(paramName, f.output(r), f.output(&adjusted))
+	}
+	return &adjusted, nil
+}
+
 // Run make the filter running.
 func (f *TimeRangeFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -398,13 +753,11 @@ func (f *TimeRangeFilter) Run(paramName string, paramValue interface{}) (interfa
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		var err error
-		timeRange, err = types.ParseTimeRange(val, f.layout, timeLoc, f.defaultLeftVal, f.defaultRightVal)
+		timeRange, err = f.parseRange(val)
 		if err != nil {
 			goto parse_error
 		}
@@ -416,13 +769,23 @@ func (f *TimeRangeFilter) Run(paramName string, paramValue interface{}) (interfa
 		goto parse_error
 	}
 
+	timeRange = f.normalize(timeRange)
+	if f.clamp {
+		var err error
+		if timeRange, err = f.clampValue(paramName, timeRange); err == errClampedRangeEmpty {
+			return nil, NewError(ErrorInvalidParam, paramName, "RangeEmptyAfterClamp")
+		} else if err != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+	}
+
 	for _, validator := range f.validators {
 		if err := validator(paramName, timeRange); err != nil {
 			return nil, err
 		}
 	}
 
-	return timeRange, nil
+	return f.output(timeRange), nil
 
 parse_error:
 	return nil, NewError(ErrorInvalidParam, paramName, "NotTimeRange")
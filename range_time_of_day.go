@@ -0,0 +1,195 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ClockRange is a range of time of day, it crosses midnight if End is not
+// after Start, eg: 22:00-02:00. Start is inclusive and End is exclusive.
+type ClockRange struct {
+	Start ClockTime
+	End   ClockTime
+}
+
+var errInvalidClockRange = errors.New("invalid time of day range")
+
+// ParseClockRange parse a time of day range like "09:00-18:00" or
+// "22:00~02:00".
+func ParseClockRange(s string) (*ClockRange, error) {
+	sep := "-"
+	if strings.Contains(s, "~") {
+		sep = "~"
+	}
+	parts := strings.Split(s, sep)
+	if len(parts) != 2 {
+		return nil, errInvalidClockRange
+	}
+	start, err := ParseClockTime(strings.TrimSpace(parts[0]))
+	if err != nil || start == EndOfDay {
+		return nil, errInvalidClockRange
+	}
+	end, err := ParseClockTime(strings.TrimSpace(parts[1]))
+	if err != nil || start == end {
+		return nil, errInvalidClockRange
+	}
+	return &ClockRange{start, end}, nil
+}
+
+// Wraps check whether the range crosses midnight.
+func (r *ClockRange) Wraps() bool {
+	return r.End < r.Start
+}
+
+// Length return the length of range.
+func (r *ClockRange) Length() time.Duration {
+	if r.Wraps() {
+		return time.Duration(EndOfDay - r.Start + r.End)
+	}
+	return time.Duration(r.End - r.Start)
+}
+
+// Contains check whether the time of day is in range.
+func (r *ClockRange) Contains(c ClockTime) bool {
+	if r.Wraps() {
+		return c >= r.Start || c < r.End
+	}
+	return c >= r.Start && c < r.End
+}
+
+// String return the range in "15:04-15:04" format.
+func (r *ClockRange) String() string {
+	return r.Start.String() + "-" + r.End.String()
+}
+
+type TimeOfDayRangeFilter struct {
+	noWrap     bool
+	validators []TimeOfDayRangeValidator
+	allowVals  allowList
+}
+
+type TimeOfDayRangeValidator func(paramName string, paramValue *ClockRange) *Error
+
+// TimeOfDayRange return a time of day range filter, it accepts ranges like
+// "09:00-18:00" and "22:00-02:00" which crosses midnight, and output
+// *ClockRange.
+func TimeOfDayRange() *TimeOfDayRangeFilter {
+	f := new(TimeOfDayRangeFilter)
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *TimeOfDayRangeFilter) Allow(vals ...string) *TimeOfDayRangeFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *TimeOfDayRangeFilter) AllowSpecial(special Special, vals ...string) *TimeOfDayRangeFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *TimeOfDayRangeFilter) AllowSpecialFold(special Special, vals ...string) *TimeOfDayRangeFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// NoWrap reject ranges crossing midnight.
+func (f *TimeOfDayRangeFilter) NoWrap() *TimeOfDayRangeFilter {
+	f.noWrap = true
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *TimeOfDayRangeFilter) AddValidator(validator TimeOfDayRangeValidator) *TimeOfDayRangeFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// MinLength valid whether the length of range is not shorter than the specified duration.
+func (f *TimeOfDayRangeFilter) MinLength(d time.Duration) *TimeOfDayRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *ClockRange) *Error {
+		if paramValue.Length() < d {
+			return NewError(ErrorInvalidParam, paramName, "TooShort")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxLength valid whether the length of range is not longer than the specified duration.
+func (f *TimeOfDayRangeFilter) MaxLength(d time.Duration) *TimeOfDayRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *ClockRange) *Error {
+		if paramValue.Length() > d {
+			return NewError(ErrorInvalidParam, paramName, "TooLong")
+		}
+		return nil
+	})
+	return f
+}
+
+// Within valid whether the range is inside the specified range, eg:
+// Within("08:00-20:00").
+func (f *TimeOfDayRangeFilter) Within(outer string) *TimeOfDayRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *ClockRange) *Error {
+		r, err := ParseClockRange(outer)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		// compare as offsets from the start of outer range
+		start := (paramValue.Start - r.Start + EndOfDay) % EndOfDay
+		if time.Duration(start)+paramValue.Length() > r.Length() {
+			return NewError(ErrorInvalidParam, paramName, "OutOfRange")
+		}
+		return nil
+	})
+	return f
+}
+
+// Run make the filter running.
+func (f *TimeOfDayRangeFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var clockRange *ClockRange
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		r, err := ParseClockRange(val)
+		if err != nil {
+			goto parse_error
+		}
+		clockRange = r
+	case *ClockRange:
+		clockRange = val
+	case ClockRange:
+		clockRange = &val
+	default:
+		goto parse_error
+	}
+
+	if f.noWrap && clockRange.Wraps() {
+		return nil, NewError(ErrorInvalidParam, paramName, "WrongRange")
+	}
+	for _, validator := range f.validators {
+		if err := validator(paramName, clockRange); err != nil {
+			return nil, err
+		}
+	}
+
+	return clockRange, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotTimeOfDayRange")
+}
@@ -0,0 +1,46 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-apibox/types"
+)
+
+func TestTimeRangeCalendarDistance(t *testing.T) {
+	testRun(t, []runCase{
+		{TimeRange().MaxCalendarDistance("1M"), "2024-01-31~2024-02-29", "[2024-01-31 00:00:00,2024-02-29 00:00:00]", ""},
+		{TimeRange().MaxCalendarDistance("1M"), "2024-01-31~2024-03-01", "", "TooFar"},
+		{TimeRange().MinCalendarDistance("1M"), "2024-01-31~2024-02-28", "", "TooNear"},
+		{TimeRange().MinCalendarDistance("1y"), "2023-02-28~2024-02-28", "[2023-02-28 00:00:00,2024-02-28 00:00:00]", ""},
+		{TimeRange().MaxCalendarDistance("1Q"), "[2024-01-01,2024-04-02)", "[2024-01-01 00:00:00,2024-04-02 00:00:00)", ""},
+		{TimeRange().MaxCalendarDistance("1Q"), "[2024-01-01,2024-04-03)", "", "TooFar"},
+		{TimeRange().MaxCalendarDistance("2w"), "2024-01-01~2024-01-16", "", "TooFar"},
+		{TimeRange().MaxCalendarDistance("1h"), "2024-01-01~2024-01-02", "", "InvalidValidator"},
+	})
+}
+
+func TestTimestampRangeCalendarDistance(t *testing.T) {
+	ts := func(s string) uint32 {
+		tm, _ := time.ParseInLocation(LayoutDate, s, timeLoc)
+		return uint32(tm.Unix())
+	}
+	r := func(left, right string) *types.TimestampRange {
+		return &types.TimestampRange{Left: ts(left), Right: ts(right), LeftClosed: true, RightClosed: true}
+	}
+	tests := []struct {
+		f    *TimestampRangeFilter
+		in   *types.TimestampRange
+		word string
+	}{
+		{TimestampRange().MaxCalendarDistance("1M"), r("2024-01-31", "2024-02-29"), ""},
+		{TimestampRange().MaxCalendarDistance("1M"), r("2024-01-31", "2024-03-01"), "TooFar"},
+		{TimestampRange().MinCalendarDistance("3M"), r("2024-01-01", "2024-03-31"), "TooNear"},
+		{TimestampRange().MinCalendarDistance("3M"), r("2024-01-01", "2024-04-01"), ""},
+	}
+	for i, tt := range tests {
+		if _, err := tt.f.Run("p", tt.in); errWord(err) != tt.word {
+			t.Errorf("#%d Run = %v, want %q", i, err, tt.word)
+		}
+	}
+}
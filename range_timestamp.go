@@ -3,15 +3,24 @@ package filter
 import (
 	"math"
 	"strings"
+	"time"
 
 	"github.com/go-apibox/types"
 )
 
 type TimestampRangeFilter struct {
-	defaultLeftVal  uint32
-	defaultRightVal uint32
-	validators      []TimestampRangeValidator
-	allowVals       []string
+	strict           strictMode
+	defaultLeftVal   uint32
+	defaultRightVal  uint32
+	defaultLeftFunc  func() uint32
+	defaultRightFunc func() uint32
+	clamp            bool
+	clampMin         uint32
+	clampMax         uint32
+	clampErr         error
+	clampHook        ClampHook
+	validators       []TimestampRangeValidator
+	allowVals        allowList
 }
 
 type TimestampRangeValidator func(paramName string, paramValue *types.TimestampRange) *Error
@@ -26,21 +35,84 @@ func TimestampRange() *TimestampRangeFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *TimestampRangeFilter) Allow(vals ...string) *TimestampRangeFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *TimestampRangeFilter) AllowSpecial(special Special, vals ...string) *TimestampRangeFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *TimestampRangeFilter) AllowSpecialFold(special Special, vals ...string) *TimestampRangeFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
 // LeftDefault set the default left value of range if not specified.
 func (f *TimestampRangeFilter) LeftDefault(val uint32) *TimestampRangeFilter {
 	f.defaultLeftVal = val
+	f.defaultLeftFunc = nil
 	return f
 }
 
 // RightDefault set the default right value of range if not specified.
 func (f *TimestampRangeFilter) RightDefault(val uint32) *TimestampRangeFilter {
 	f.defaultRightVal = val
+	f.defaultRightFunc = nil
+	return f
+}
+
+// LeftDefaultFunc set the provider of default left value of range, it is
+// called on each run.
+func (f *TimestampRangeFilter) LeftDefaultFunc(provider func() uint32) *TimestampRangeFilter {
+	f.defaultLeftFunc = provider
+	return f
+}
+
+// RightDefaultFunc set the provider of default right value of range, it is
+// called on each run, eg: NowTimestamp.
+func (f *TimestampRangeFilter) RightDefaultFunc(provider func() uint32) *TimestampRangeFilter {
+	f.defaultRightFunc = provider
+	return f
+}
+
+// Clamp move sides of range out of [min, max] onto the nearest limit instead
+// of failing, a moved side becomes closed and a range left empty is rejected.
+func (f *TimestampRangeFilter) Clamp(min, max uint32) *TimestampRangeFilter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *TimestampRangeFilter) OnClamp(hook ClampHook) *TimestampRangeFilter {
+	f.clampHook = hook
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *TimestampRangeFilter) Strict() *TimestampRangeFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *TimestampRangeFilter) Lenient() *TimestampRangeFilter {
+	f.strict = strictOff
 	return f
 }
 
@@ -316,6 +388,304 @@ func (f *TimestampRangeFilter) MaxDistance(val uint32) *TimestampRangeFilter {
 	return f
 }
 
+// MinCalendarDistance valid whether the distance of range not smaller than the
+// specified calendar distance, eg: "1d", "2w", "3M", "1Q", "1y" or "1y6M".
+func (f *TimestampRangeFilter) MinCalendarDistance(expr string) *TimestampRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimestampRange) *Error {
+		left, right, ok := timestampRangeEnds(paramValue)
+		if !ok {
+			return NewError(ErrorInvalidParam, paramName, "WrongRange")
+		}
+		c, err := compareCalendarDistance(left, right, expr, timeLoc)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if c < 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooNear")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// MaxCalendarDistance valid whether the distance of range not larger than the
+// specified calendar distance, eg: "1d", "2w", "3M", "1Q", "1y" or "1y6M".
+func (f *TimestampRangeFilter) MaxCalendarDistance(expr string) *TimestampRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimestampRange) *Error {
+		left, right, ok := timestampRangeEnds(paramValue)
+		if !ok {
+			return NewError(ErrorInvalidParam, paramName, "WrongRange")
+		}
+		c, err := compareCalendarDistance(left, right, expr, timeLoc)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		if c > 0 {
+			return NewError(ErrorInvalidParam, paramName, "TooFar")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// timestampRangeEnds return both ends of range as time excluding the open ends.
+func timestampRangeEnds(r *types.TimestampRange) (time.Time, time.Time, bool) {
+	left, right := int64(r.Left), int64(r.Right)
+	if !r.LeftClosed {
+		left++
+	}
+	if !r.RightClosed {
+		right--
+	}
+	if left > right {
+		return time.Time{}, time.Time{}, false
+	}
+	return time.Unix(left, 0), time.Unix(right, 0), true
+}
+
+// LeftStartFromRelative valid whether left value of range is start from the time of
+// relative expression. The expression is evaluated on each run.
+func (f *TimestampRangeFilter) LeftStartFromRelative(expr string) *TimestampRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimestampRange) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.LeftClosed {
+			t = t - 1
+		}
+		if int64(paramValue.Left) < t {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftEndToRelative valid whether left value of range is end to the time of
+// relative expression. The expression is evaluated on each run.
+func (f *TimestampRangeFilter) LeftEndToRelative(expr string) *TimestampRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimestampRange) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.LeftClosed {
+			t = t - 1
+		}
+		if int64(paramValue.Left) > t {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftAfterRelative valid whether left value of range is after the time of
+// relative expression. The expression is evaluated on each run.
+func (f *TimestampRangeFilter) LeftAfterRelative(expr string) *TimestampRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimestampRange) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.LeftClosed {
+			t = t - 1
+		}
+		if int64(paramValue.Left) <= t {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftBeforeRelative valid whether left value of range is before the time of
+// relative expression. The expression is evaluated on each run.
+func (f *TimestampRangeFilter) LeftBeforeRelative(expr string) *TimestampRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimestampRange) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.LeftClosed {
+			t = t - 1
+		}
+		if int64(paramValue.Left) >= t {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftBetweenRelative valid whether left value of range is between the times
+// of two relative expressions. The expressions are evaluated on each run.
+func (f *TimestampRangeFilter) LeftBetweenRelative(startExpr, endExpr string) *TimestampRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimestampRange) *Error {
+		start, err := parseTimestampBound(startExpr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		end, err := parseTimestampBound(endExpr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.LeftClosed {
+			start = start - 1
+			end = end - 1
+		}
+		if int64(paramValue.Left) < start {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
+		}
+		if int64(paramValue.Left) > end {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightStartFromRelative valid whether right value of range is start from the time of
+// relative expression. The expression is evaluated on each run.
+func (f *TimestampRangeFilter) RightStartFromRelative(expr string) *TimestampRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimestampRange) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.RightClosed {
+			t = t + 1
+		}
+		if int64(paramValue.Right) < t {
+			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightEndToRelative valid whether right value of range is end to the time of
+// relative expression. The expression is evaluated on each run.
+func (f *TimestampRangeFilter) RightEndToRelative(expr string) *TimestampRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimestampRange) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.RightClosed {
+			t = t + 1
+		}
+		if int64(paramValue.Right) > t {
+			return NewError(ErrorInvalidParam, paramName, "RightTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightAfterRelative valid whether right value of range is after the time of
+// relative expression. The expression is evaluated on each run.
+func (f *TimestampRangeFilter) RightAfterRelative(expr string) *TimestampRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimestampRange) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.RightClosed {
+			t = t + 1
+		}
+		if int64(paramValue.Right) <= t {
+			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightBeforeRelative valid whether right value of range is before the time of
+// relative expression. The expression is evaluated on each run.
+func (f *TimestampRangeFilter) RightBeforeRelative(expr string) *TimestampRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimestampRange) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.RightClosed {
+			t = t + 1
+		}
+		if int64(paramValue.Right) >= t {
+			return NewError(ErrorInvalidParam, paramName, "RightTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightBetweenRelative valid whether right value of range is between the times
+// of two relative expressions. The expressions are evaluated on each run.
+func (f *TimestampRangeFilter) RightBetweenRelative(startExpr, endExpr string) *TimestampRangeFilter {
+	f.AddValidator(func(paramName string, paramValue *types.TimestampRange) *Error {
+		start, err := parseTimestampBound(startExpr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		end, err := parseTimestampBound(endExpr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.RightClosed {
+			start = start + 1
+			end = end + 1
+		}
+		if int64(paramValue.Right) < start {
+			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
+		}
+		if int64(paramValue.Right) > end {
+			return NewError(ErrorInvalidParam, paramName, "RightTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// clampValue move both sides of r onto the nearest limit of clamp range.
+func (f *TimestampRangeFilter) clampValue(paramName string, r *types.TimestampRange) (*types.TimestampRange, error) {
+	adjusted := *r
+	if adjusted.Left < f.clampMin {
+		adjusted.Left, adjusted.LeftClosed = f.clampMin, true
+	} else if adjusted.Left > f.clampMax {
+		adjusted.Left, adjusted.LeftClosed = f.clampMax, true
+	}
+	if adjusted.Right < f.clampMin {
+		adjusted.Right, adjusted.RightClosed = f.clampMin, true
+	} else if adjusted.Right > f.clampMax {
+		adjusted.Right, adjusted.RightClosed = f.clampMax, true
+	}
+	if adjusted == *r {
+		return r, nil
+	}
+	if adjusted.Left > adjusted.Right || adjusted.Left == adjusted.Right && !(adjusted.LeftClosed && adjusted.RightClosed) {
+		return nil, errClampedRangeEmpty
+	}
+	if f.clampHook != nil {
+		f.clampHook(paramName, r, &adjusted)
+	}
+	return &adjusted, nil
+}
+
 // Run make the filter running.
 func (f *TimestampRangeFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -325,14 +695,23 @@ func (f *TimestampRangeFilter) Run(paramName string, paramValue interface{}) (in
 	var tsRange *types.TimestampRange
 	switch val := paramValue.(type) {
 	case string:
+		raw := val
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.checkRange(raw, 10, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		defaultLeftVal, defaultRightVal := f.defaultLeftVal, f.defaultRightVal
+		if f.defaultLeftFunc != nil {
+			defaultLeftVal = f.defaultLeftFunc()
+		}
+		if f.defaultRightFunc != nil {
+			defaultRightVal = f.defaultRightFunc()
 		}
 		var err error
-		tsRange, err = types.ParseTimestampRange(val, f.defaultLeftVal, f.defaultRightVal)
+		tsRange, err = types.ParseTimestampRange(val, defaultLeftVal, defaultRightVal)
 		if err != nil {
 			goto parse_error
 		}
@@ -341,9 +720,32 @@ func (f *TimestampRangeFilter) Run(paramName string, paramValue interface{}) (in
 	case types.TimestampRange:
 		tsRange = &val
 	default:
-		goto parse_error
+		defaultLeftVal, defaultRightVal := f.defaultLeftVal, f.defaultRightVal
+		if f.defaultLeftFunc != nil {
+			defaultLeftVal = f.defaultLeftFunc()
+		}
+		if f.defaultRightFunc != nil {
+			defaultRightVal = f.defaultRightFunc()
+		}
+		left, right, word, ok := coerceUint64Range(val, math.MaxUint32, uint64(defaultLeftVal), uint64(defaultRightVal))
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		tsRange = &types.TimestampRange{Left: uint32(left), Right: uint32(right), LeftClosed: true, RightClosed: true}
 	}
 
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		var err error
+		if tsRange, err = f.clampValue(paramName, tsRange); err != nil {
+			return nil, NewError(ErrorInvalidParam, paramName, "RangeEmptyAfterClamp")
+		}
+	}
 	for _, validator := range f.validators {
 		if err := validator(paramName, tsRange); err != nil {
 			return nil, err
@@ -0,0 +1,775 @@
+package filter
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// TimestampRange64 is a range of int64 timestamps.
+type TimestampRange64 struct {
+	Left        int64
+	Right       int64
+	LeftClosed  bool
+	RightClosed bool
+}
+
+var errInvalidTimestamp64Range = errors.New("invalid timestamp64 range")
+
+type Timestamp64RangeFilter struct {
+	strict           strictMode
+	unit             TimestampUnit
+	defaultLeftVal   int64
+	defaultRightVal  int64
+	defaultLeftFunc  func() int64
+	defaultRightFunc func() int64
+	clamp            bool
+	clampMin         int64
+	clampMax         int64
+	clampErr         error
+	clampHook        ClampHook
+	validators       []Timestamp64RangeValidator
+	allowVals        allowList
+}
+
+type Timestamp64RangeValidator func(paramName string, paramValue *TimestampRange64) *Error
+
+// Timestamp64Range return a int64 timestamp range filter, the unit is second
+// by default.
+func Timestamp64Range() *Timestamp64RangeFilter {
+	f := new(Timestamp64RangeFilter)
+	f.unit = TimestampSecond
+
+	f.defaultLeftVal = math.MinInt64
+	f.defaultRightVal = math.MaxInt64
+
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *Timestamp64RangeFilter) Allow(vals ...string) *Timestamp64RangeFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *Timestamp64RangeFilter) AllowSpecial(special Special, vals ...string) *Timestamp64RangeFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *Timestamp64RangeFilter) AllowSpecialFold(special Special, vals ...string) *Timestamp64RangeFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Unit set the unit of timestamp, eg: TimestampMilli for javascript timestamps.
+func (f *Timestamp64RangeFilter) Unit(unit TimestampUnit) *Timestamp64RangeFilter {
+	f.unit = unit
+	return f
+}
+
+// LeftDefault set the default left value of range if not specified.
+func (f *Timestamp64RangeFilter) LeftDefault(val int64) *Timestamp64RangeFilter {
+	f.defaultLeftVal = val
+	f.defaultLeftFunc = nil
+	return f
+}
+
+// RightDefault set the default right value of range if not specified.
+func (f *Timestamp64RangeFilter) RightDefault(val int64) *Timestamp64RangeFilter {
+	f.defaultRightVal = val
+	f.defaultRightFunc = nil
+	return f
+}
+
+// LeftDefaultFunc set the provider of default left value of range, it is
+// called on each run.
+func (f *Timestamp64RangeFilter) LeftDefaultFunc(provider func() int64) *Timestamp64RangeFilter {
+	f.defaultLeftFunc = provider
+	return f
+}
+
+// RightDefaultFunc set the provider of default right value of range, it is
+// called on each run.
+func (f *Timestamp64RangeFilter) RightDefaultFunc(provider func() int64) *Timestamp64RangeFilter {
+	f.defaultRightFunc = provider
+	return f
+}
+
+// Clamp move sides of range out of [min, max] onto the nearest limit instead
+// of failing, a moved side becomes closed and a range left empty is rejected.
+func (f *Timestamp64RangeFilter) Clamp(min, max int64) *Timestamp64RangeFilter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *Timestamp64RangeFilter) OnClamp(hook ClampHook) *Timestamp64RangeFilter {
+	f.clampHook = hook
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *Timestamp64RangeFilter) Strict() *Timestamp64RangeFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *Timestamp64RangeFilter) Lenient() *Timestamp64RangeFilter {
+	f.strict = strictOff
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *Timestamp64RangeFilter) AddValidator(validator Timestamp64RangeValidator) *Timestamp64RangeFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// LeftStartFrom valid whether left value of range is start from specified time.
+func (f *Timestamp64RangeFilter) LeftStartFrom(val int64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		bound := val
+		if !paramValue.LeftClosed {
+			if bound > math.MinInt64 {
+				bound = bound - 1
+			}
+		}
+		if paramValue.Left < bound {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftEndTo valid whether left value of range is end to specified time.
+func (f *Timestamp64RangeFilter) LeftEndTo(val int64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		bound := val
+		if !paramValue.LeftClosed {
+			if bound > math.MinInt64 {
+				bound = bound - 1
+			}
+		}
+		if paramValue.Left > bound {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftAfter valid whether left value of range is after specified time.
+func (f *Timestamp64RangeFilter) LeftAfter(val int64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		bound := val
+		if !paramValue.LeftClosed {
+			if bound > math.MinInt64 {
+				bound = bound - 1
+			}
+		}
+		if paramValue.Left <= bound {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftBefore valid whether left value of range is before specified time.
+func (f *Timestamp64RangeFilter) LeftBefore(val int64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		bound := val
+		if !paramValue.LeftClosed {
+			if bound > math.MinInt64 {
+				bound = bound - 1
+			}
+		}
+		if paramValue.Left >= bound {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftEqual valid whether left value of range is equal to specified time.
+func (f *Timestamp64RangeFilter) LeftEqual(val int64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		bound := val
+		if !paramValue.LeftClosed {
+			if bound > math.MinInt64 {
+				bound = bound - 1
+			}
+		}
+		if paramValue.Left < bound {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
+		}
+		if paramValue.Left > bound {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftBetween valid whether left value of range is in the specified range.
+func (f *Timestamp64RangeFilter) LeftBetween(start, end int64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		lo, hi := start, end
+		if !paramValue.LeftClosed {
+			if lo > math.MinInt64 {
+				lo = lo - 1
+			}
+			if hi > math.MinInt64 {
+				hi = hi - 1
+			}
+		}
+		if paramValue.Left < lo {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
+		}
+		if paramValue.Left > hi {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftStartFromRelative valid whether left value of range is start from the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64RangeFilter) LeftStartFromRelative(expr string) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.LeftClosed {
+			if t > math.MinInt64 {
+				t = t - 1
+			}
+		}
+		if paramValue.Left < t {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftEndToRelative valid whether left value of range is end to the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64RangeFilter) LeftEndToRelative(expr string) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.LeftClosed {
+			if t > math.MinInt64 {
+				t = t - 1
+			}
+		}
+		if paramValue.Left > t {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftAfterRelative valid whether left value of range is after the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64RangeFilter) LeftAfterRelative(expr string) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.LeftClosed {
+			if t > math.MinInt64 {
+				t = t - 1
+			}
+		}
+		if paramValue.Left <= t {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftBeforeRelative valid whether left value of range is before the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64RangeFilter) LeftBeforeRelative(expr string) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.LeftClosed {
+			if t > math.MinInt64 {
+				t = t - 1
+			}
+		}
+		if paramValue.Left >= t {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// LeftBetweenRelative valid whether left value of range is between the times
+// of two relative expressions. The expressions are evaluated on each run.
+func (f *Timestamp64RangeFilter) LeftBetweenRelative(startExpr, endExpr string) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		start, err := parseTimestamp64Bound(startExpr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		end, err := parseTimestamp64Bound(endExpr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.LeftClosed {
+			if start > math.MinInt64 {
+				start = start - 1
+			}
+			if end > math.MinInt64 {
+				end = end - 1
+			}
+		}
+		if paramValue.Left < start {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooEarly")
+		}
+		if paramValue.Left > end {
+			return NewError(ErrorInvalidParam, paramName, "LeftTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightStartFrom valid whether right value of range is start from specified time.
+func (f *Timestamp64RangeFilter) RightStartFrom(val int64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		bound := val
+		if !paramValue.RightClosed {
+			if bound < math.MaxInt64 {
+				bound = bound + 1
+			}
+		}
+		if paramValue.Right < bound {
+			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightEndTo valid whether right value of range is end to specified time.
+func (f *Timestamp64RangeFilter) RightEndTo(val int64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		bound := val
+		if !paramValue.RightClosed {
+			if bound < math.MaxInt64 {
+				bound = bound + 1
+			}
+		}
+		if paramValue.Right > bound {
+			return NewError(ErrorInvalidParam, paramName, "RightTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightAfter valid whether right value of range is after specified time.
+func (f *Timestamp64RangeFilter) RightAfter(val int64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		bound := val
+		if !paramValue.RightClosed {
+			if bound < math.MaxInt64 {
+				bound = bound + 1
+			}
+		}
+		if paramValue.Right <= bound {
+			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightBefore valid whether right value of range is before specified time.
+func (f *Timestamp64RangeFilter) RightBefore(val int64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		bound := val
+		if !paramValue.RightClosed {
+			if bound < math.MaxInt64 {
+				bound = bound + 1
+			}
+		}
+		if paramValue.Right >= bound {
+			return NewError(ErrorInvalidParam, paramName, "RightTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightEqual valid whether right value of range is equal to specified time.
+func (f *Timestamp64RangeFilter) RightEqual(val int64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		bound := val
+		if !paramValue.RightClosed {
+			if bound < math.MaxInt64 {
+				bound = bound + 1
+			}
+		}
+		if paramValue.Right < bound {
+			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
+		}
+		if paramValue.Right > bound {
+			return NewError(ErrorInvalidParam, paramName, "RightTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightBetween valid whether right value of range is in the specified range.
+func (f *Timestamp64RangeFilter) RightBetween(start, end int64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		lo, hi := start, end
+		if !paramValue.RightClosed {
+			if lo < math.MaxInt64 {
+				lo = lo + 1
+			}
+			if hi < math.MaxInt64 {
+				hi = hi + 1
+			}
+		}
+		if paramValue.Right < lo {
+			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
+		}
+		if paramValue.Right > hi {
+			return NewError(ErrorInvalidParam, paramName, "RightTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightStartFromRelative valid whether right value of range is start from the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64RangeFilter) RightStartFromRelative(expr string) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.RightClosed {
+			if t < math.MaxInt64 {
+				t = t + 1
+			}
+		}
+		if paramValue.Right < t {
+			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightEndToRelative valid whether right value of range is end to the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64RangeFilter) RightEndToRelative(expr string) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.RightClosed {
+			if t < math.MaxInt64 {
+				t = t + 1
+			}
+		}
+		if paramValue.Right > t {
+			return NewError(ErrorInvalidParam, paramName, "RightTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightAfterRelative valid whether right value of range is after the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64RangeFilter) RightAfterRelative(expr string) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.RightClosed {
+			if t < math.MaxInt64 {
+				t = t + 1
+			}
+		}
+		if paramValue.Right <= t {
+			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightBeforeRelative valid whether right value of range is before the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64RangeFilter) RightBeforeRelative(expr string) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.RightClosed {
+			if t < math.MaxInt64 {
+				t = t + 1
+			}
+		}
+		if paramValue.Right >= t {
+			return NewError(ErrorInvalidParam, paramName, "RightTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// RightBetweenRelative valid whether right value of range is between the times
+// of two relative expressions. The expressions are evaluated on each run.
+func (f *Timestamp64RangeFilter) RightBetweenRelative(startExpr, endExpr string) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		start, err := parseTimestamp64Bound(startExpr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		end, err := parseTimestamp64Bound(endExpr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if !paramValue.RightClosed {
+			if start < math.MaxInt64 {
+				start = start + 1
+			}
+			if end < math.MaxInt64 {
+				end = end + 1
+			}
+		}
+		if paramValue.Right < start {
+			return NewError(ErrorInvalidParam, paramName, "RightTooEarly")
+		}
+		if paramValue.Right > end {
+			return NewError(ErrorInvalidParam, paramName, "RightTooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// distance return the distance of range excluding the open ends.
+func (r *TimestampRange64) distance() (uint64, bool) {
+	dist := uint64(r.Right) - uint64(r.Left)
+	if !r.LeftClosed {
+		if dist == 0 {
+			return 0, false
+		}
+		dist = dist - 1
+	}
+	if !r.RightClosed {
+		if dist == 0 {
+			return 0, false
+		}
+		dist = dist - 1
+	}
+	return dist, true
+}
+
+// MinDistance valid whether the distance of range not smaller than the specified value.
+func (f *Timestamp64RangeFilter) MinDistance(val uint64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		dist, ok := paramValue.distance()
+		if !ok {
+			return NewError(ErrorInvalidParam, paramName, "WrongRange")
+		}
+		if dist < val {
+			return NewError(ErrorInvalidParam, paramName, "TooNear")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxDistance valid whether the distance of range not larger than the specified value.
+func (f *Timestamp64RangeFilter) MaxDistance(val uint64) *Timestamp64RangeFilter {
+	f.AddValidator(func(paramName string, paramValue *TimestampRange64) *Error {
+		dist, ok := paramValue.distance()
+		if !ok {
+			return NewError(ErrorInvalidParam, paramName, "WrongRange")
+		}
+		if dist > val {
+			return NewError(ErrorInvalidParam, paramName, "TooFar")
+		}
+		return nil
+	})
+	return f
+}
+
+// parseRange parse a timestamp range string like "[1700000000,1700086400)",
+// an empty side takes the default value.
+func (f *Timestamp64RangeFilter) parseRange(s string) (*TimestampRange64, error) {
+	left, right, leftClosed, rightClosed, ok := splitRange(s)
+	if !ok {
+		return nil, errInvalidTimestamp64Range
+	}
+
+	r := &TimestampRange64{f.defaultLeftVal, f.defaultRightVal, leftClosed, rightClosed}
+	if f.defaultLeftFunc != nil {
+		r.Left = f.defaultLeftFunc()
+	}
+	if f.defaultRightFunc != nil {
+		r.Right = f.defaultRightFunc()
+	}
+	var err error
+	if left != "" {
+		if r.Left, err = parseTimestamp64(left); err != nil {
+			return nil, err
+		}
+	}
+	if right != "" {
+		if r.Right, err = parseTimestamp64(right); err != nil {
+			return nil, err
+		}
+	}
+	if r.Left > r.Right {
+		return nil, errInvalidTimestamp64Range
+	}
+	return r, nil
+}
+
+// clampValue move both sides of r onto the nearest limit of clamp range.
+func (f *Timestamp64RangeFilter) clampValue(paramName string, r *TimestampRange64) (*TimestampRange64, error) {
+	adjusted := *r
+	if adjusted.Left < f.clampMin {
+		adjusted.Left, adjusted.LeftClosed = f.clampMin, true
+	} else if adjusted.Left > f.clampMax {
+		adjusted.Left, adjusted.LeftClosed = f.clampMax, true
+	}
+	if adjusted.Right < f.clampMin {
+		adjusted.Right, adjusted.RightClosed = f.clampMin, true
+	} else if adjusted.Right > f.clampMax {
+		adjusted.Right, adjusted.RightClosed = f.clampMax, true
+	}
+	if adjusted == *r {
+		return r, nil
+	}
+	if adjusted.Left > adjusted.Right || adjusted.Left == adjusted.Right && !(adjusted.LeftClosed && adjusted.RightClosed) {
+		return nil, errClampedRangeEmpty
+	}
+	if f.clampHook != nil {
+		f.clampHook(paramName, r, &adjusted)
+	}
+	return &adjusted, nil
+}
+
+// Run make the filter running.
+func (f *Timestamp64RangeFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var tsRange *TimestampRange64
+	switch val := paramValue.(type) {
+	case string:
+		raw := val
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.checkRange(raw, 10, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		var err error
+		tsRange, err = f.parseRange(val)
+		if err == errTimestampOverflow {
+			return nil, NewError(ErrorInvalidParam, paramName, "TimestampOverflow")
+		}
+		if err != nil {
+			goto parse_error
+		}
+	case *TimestampRange64:
+		tsRange = val
+	case TimestampRange64:
+		tsRange = &val
+	default:
+		defaultLeftVal, defaultRightVal := f.defaultLeftVal, f.defaultRightVal
+		if f.defaultLeftFunc != nil {
+			defaultLeftVal = f.defaultLeftFunc()
+		}
+		if f.defaultRightFunc != nil {
+			defaultRightVal = f.defaultRightFunc()
+		}
+		left, right, word, ok := coerceInt64Range(val, math.MinInt64, math.MaxInt64, defaultLeftVal, defaultRightVal)
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		tsRange = &TimestampRange64{Left: left, Right: right, LeftClosed: true, RightClosed: true}
+	}
+
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		var err error
+		if tsRange, err = f.clampValue(paramName, tsRange); err != nil {
+			return nil, NewError(ErrorInvalidParam, paramName, "RangeEmptyAfterClamp")
+		}
+	}
+	for _, validator := range f.validators {
+		if err := validator(paramName, tsRange); err != nil {
+			return nil, err
+		}
+	}
+
+	return tsRange, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotTimestamp64Range")
+}
@@ -7,10 +7,16 @@ import (
 )
 
 type UintRangeFilter struct {
+	strict          strictMode
 	defaultLeftVal  uint
 	defaultRightVal uint
+	clamp           bool
+	clampMin        uint
+	clampMax        uint
+	clampErr        error
+	clampHook       ClampHook
 	validators      []UintRangeValidator
-	allowVals       []string
+	allowVals       allowList
 }
 
 type UintRangeValidator func(paramName string, paramValue *types.UintRange) *Error
@@ -25,9 +31,24 @@ func UintRange() *UintRangeFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *UintRangeFilter) Allow(vals ...string) *UintRangeFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *UintRangeFilter) AllowSpecial(special Special, vals ...string) *UintRangeFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *UintRangeFilter) AllowSpecialFold(special Special, vals ...string) *UintRangeFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -43,6 +64,38 @@ func (f *UintRangeFilter) RightDefault(val uint) *UintRangeFilter {
 	return f
 }
 
+// Clamp move sides of range out of [min, max] onto the nearest limit instead
+// of failing, a moved side becomes closed and a range left empty is rejected.
+func (f *UintRangeFilter) Clamp(min, max uint) *UintRangeFilter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *UintRangeFilter) OnClamp(hook ClampHook) *UintRangeFilter {
+	f.clampHook = hook
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *UintRangeFilter) Strict() *UintRangeFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *UintRangeFilter) Lenient() *UintRangeFilter {
+	f.strict = strictOff
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *UintRangeFilter) AddValidator(validator UintRangeValidator) *UintRangeFilter {
 	f.validators = append(f.validators, validator)
@@ -315,6 +368,31 @@ func (f *UintRangeFilter) MaxDistance(val uint) *UintRangeFilter {
 	return f
 }
 
+// clampValue move both sides of r onto the nearest limit of clamp range.
+func (f *UintRangeFilter) clampValue(paramName string, r *types.UintRange) (*types.UintRange, error) {
+	adjusted := *r
+	if adjusted.Left < f.clampMin {
+		adjusted.Left, adjusted.LeftClosed = f.clampMin, true
+	} else if adjusted.Left > f.clampMax {
+		adjusted.Left, adjusted.LeftClosed = f.clampMax, true
+	}
+	if adjusted.Right < f.clampMin {
+		adjusted.Right, adjusted.RightClosed = f.clampMin, true
+	} else if adjusted.Right > f.clampMax {
+		adjusted.Right, adjusted.RightClosed = f.clampMax, true
+	}
+	if adjusted == *r {
+		return r, nil
+	}
+	if adjusted.Left > adjusted.Right || adjusted.Left == adjusted.Right && !(adjusted.LeftClosed && adjusted.RightClosed) {
+		return nil, errClampedRangeEmpty
+	}
+	if f.clampHook != nil {
+		f.clampHook(paramName, r, &adjusted)
+	}
+	return &adjusted, nil
+}
+
 // Run make the filter running.
 func (f *UintRangeFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -324,11 +402,13 @@ func (f *UintRangeFilter) Run(paramName string, paramValue interface{}) (interfa
 	var uintRange *types.UintRange
 	switch val := paramValue.(type) {
 	case string:
+		raw := val
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.checkRange(raw, 10, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
 		}
 		var err error
 		uintRange, err = types.ParseUintRange(val, f.defaultLeftVal, f.defaultRightVal)
@@ -340,9 +420,25 @@ func (f *UintRangeFilter) Run(paramName string, paramValue interface{}) (interfa
 	case types.UintRange:
 		uintRange = &val
 	default:
-		goto parse_error
+		left, right, word, ok := coerceUint64Range(val, uint64(types.MaxUint), uint64(f.defaultLeftVal), uint64(f.defaultRightVal))
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		uintRange = &types.UintRange{Left: uint(left), Right: uint(right), LeftClosed: true, RightClosed: true}
 	}
 
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		var err error
+		if uintRange, err = f.clampValue(paramName, uintRange); err != nil {
+			return nil, NewError(ErrorInvalidParam, paramName, "RangeEmptyAfterClamp")
+		}
+	}
 	for _, validator := range f.validators {
 		if err := validator(paramName, uintRange); err != nil {
 			return nil, err
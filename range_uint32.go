@@ -8,10 +8,16 @@ import (
 )
 
 type Uint32RangeFilter struct {
+	strict          strictMode
 	defaultLeftVal  uint32
 	defaultRightVal uint32
+	clamp           bool
+	clampMin        uint32
+	clampMax        uint32
+	clampErr        error
+	clampHook       ClampHook
 	validators      []Uint32RangeValidator
-	allowVals       []string
+	allowVals       allowList
 }
 
 type Uint32RangeValidator func(paramName string, paramValue *types.Uint32Range) *Error
@@ -26,9 +32,24 @@ func Uint32Range() *Uint32RangeFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *Uint32RangeFilter) Allow(vals ...string) *Uint32RangeFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *Uint32RangeFilter) AllowSpecial(special Special, vals ...string) *Uint32RangeFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *Uint32RangeFilter) AllowSpecialFold(special Special, vals ...string) *Uint32RangeFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -44,6 +65,38 @@ func (f *Uint32RangeFilter) RightDefault(val uint32) *Uint32RangeFilter {
 	return f
 }
 
+// Clamp move sides of range out of [min, max] onto the nearest limit instead
+// of failing, a moved side becomes closed and a range left empty is rejected.
+func (f *Uint32RangeFilter) Clamp(min, max uint32) *Uint32RangeFilter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *Uint32RangeFilter) OnClamp(hook ClampHook) *Uint32RangeFilter {
+	f.clampHook = hook
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *Uint32RangeFilter) Strict() *Uint32RangeFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *Uint32RangeFilter) Lenient() *Uint32RangeFilter {
+	f.strict = strictOff
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *Uint32RangeFilter) AddValidator(validator Uint32RangeValidator) *Uint32RangeFilter {
 	f.validators = append(f.validators, validator)
@@ -316,6 +369,31 @@ func (f *Uint32RangeFilter) MaxDistance(val uint32) *Uint32RangeFilter {
 	return f
 }
 
+// clampValue move both sides of r onto the nearest limit of clamp range.
+func (f *Uint32RangeFilter) clampValue(paramName string, r *types.Uint32Range) (*types.Uint32Range, error) {
+	adjusted := *r
+	if adjusted.Left < f.clampMin {
+		adjusted.Left, adjusted.LeftClosed = f.clampMin, true
+	} else if adjusted.Left > f.clampMax {
+		adjusted.Left, adjusted.LeftClosed = f.clampMax, true
+	}
+	if adjusted.Right < f.clampMin {
+		adjusted.Right, adjusted.RightClosed = f.clampMin, true
+	} else if adjusted.Right > f.clampMax {
+		adjusted.Right, adjusted.RightClosed = f.clampMax, true
+	}
+	if adjusted == *r {
+		return r, nil
+	}
+	if adjusted.Left > adjusted.Right || adjusted.Left == adjusted.Right && !(adjusted.LeftClosed && adjusted.RightClosed) {
+		return nil, errClampedRangeEmpty
+	}
+	if f.clampHook != nil {
+		f.clampHook(paramName, r, &adjusted)
+	}
+	return &adjusted, nil
+}
+
 // Run make the filter running.
 func (f *Uint32RangeFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -325,11 +403,13 @@ func (f *Uint32RangeFilter) Run(paramName string, paramValue interface{}) (inter
 	var uint32Range *types.Uint32Range
 	switch val := paramValue.(type) {
 	case string:
+		raw := val
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.checkRange(raw, 10, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
 		}
 		var err error
 		uint32Range, err = types.ParseUint32Range(val, f.defaultLeftVal, f.defaultRightVal)
@@ -341,9 +421,25 @@ func (f *Uint32RangeFilter) Run(paramName string, paramValue interface{}) (inter
 	case types.Uint32Range:
 		uint32Range = &val
 	default:
-		goto parse_error
+		left, right, word, ok := coerceUint64Range(val, math.MaxUint32, uint64(f.defaultLeftVal), uint64(f.defaultRightVal))
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		uint32Range = &types.Uint32Range{Left: uint32(left), Right: uint32(right), LeftClosed: true, RightClosed: true}
 	}
 
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		var err error
+		if uint32Range, err = f.clampValue(paramName, uint32Range); err != nil {
+			return nil, NewError(ErrorInvalidParam, paramName, "RangeEmptyAfterClamp")
+		}
+	}
 	for _, validator := range f.validators {
 		if err := validator(paramName, uint32Range); err != nil {
 			return nil, err
@@ -0,0 +1,781 @@
+package filter
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// recurrence frequencies, from the finest to the coarsest
+const (
+	freqSecondly = iota
+	freqMinutely
+	freqHourly
+	freqDaily
+	freqWeekly
+	freqMonthly
+	freqYearly
+)
+
+var rruleFreqs = map[string]int{
+	"SECONDLY": freqSecondly,
+	"MINUTELY": freqMinutely,
+	"HOURLY":   freqHourly,
+	"DAILY":    freqDaily,
+	"WEEKLY":   freqWeekly,
+	"MONTHLY":  freqMonthly,
+	"YEARLY":   freqYearly,
+}
+
+var rruleDays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// rruleWeekday is a BYDAY item like "MO" or "-1FR", n is 0 for every
+// weekday in period.
+type rruleWeekday struct {
+	n   int
+	day time.Weekday
+}
+
+// Recurrence is a parsed RFC 5545 recurrence rule with its start time,
+// occurrences are evaluated on the wall clock of its location.
+type Recurrence struct {
+	freq       int
+	interval   int
+	count      int
+	until      time.Time
+	byMonth    []int
+	byMonthDay []int
+	byDay      []rruleWeekday
+	byHour     []int
+	byMinute   []int
+	bySecond   []int
+	bySetPos   []int
+	wkst       time.Weekday
+	dtstart    time.Time
+	loc        *time.Location
+}
+
+type RRuleFilter struct {
+	minInterval time.Duration
+	validators  []RRuleValidator
+	allowVals   allowList
+}
+
+type RRuleValidator func(paramName string, paramValue *Recurrence) *Error
+
+var errInvalidRRule = errors.New("invalid recurrence rule")
+var errImpossibleRRule = errors.New("impossible recurrence rule")
+
+const (
+	// periods and years to search before a rule is considered impossible
+	rruleSearchPeriods = 400
+	rruleSearchYears   = 8
+	// occurrences checked by MinInterval, within rruleSearchYears
+	rruleIntervalSamples = 1000
+	// periods searched by one evaluation, rules which need more are too
+	// sparse to evaluate. Sub-daily rules skip unmatched days, hours and
+	// minutes, so they need few periods unless they are very sparse.
+	rruleMaxPeriods         = 50000
+	rruleMaxSubDailyPeriods = 20000
+)
+
+// RRule return a recurrence rule filter, it accepts "FREQ=WEEKLY;BYDAY=MO,WE"
+// optionally prefixed with "RRULE:" and preceded by a "DTSTART:..." line, and
+// output *Recurrence. Rules without DTSTART start from now in the filter's
+// location.
+func RRule() *RRuleFilter {
+	f := new(RRuleFilter)
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *RRuleFilter) Allow(vals ...string) *RRuleFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *RRuleFilter) AllowSpecial(special Special, vals ...string) *RRuleFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *RRuleFilter) AllowSpecialFold(special Special, vals ...string) *RRuleFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// MinInterval set the minimum interval between two occurrences.
+func (f *RRuleFilter) MinInterval(d time.Duration) *RRuleFilter {
+	f.minInterval = d
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *RRuleFilter) AddValidator(validator RRuleValidator) *RRuleFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// ParseRRule parse a recurrence rule, dtstart is used if there is no DTSTART
+// line. Supported parts are FREQ, INTERVAL, COUNT, UNTIL, BYMONTH,
+// BYMONTHDAY, BYDAY, BYHOUR, BYMINUTE, BYSECOND, BYSETPOS and WKST.
+// Rules which INTERVAL never lands on their BYxxx values, eg:
+// "FREQ=SECONDLY;INTERVAL=2;BYSECOND=1" from an even second, return
+// errImpossibleRRule.
+func ParseRRule(s string, dtstart time.Time, loc *time.Location) (*Recurrence, error) {
+	r := &Recurrence{interval: 1, wkst: time.Monday, loc: loc, freq: -1}
+	r.dtstart = dtstart.In(loc).Truncate(time.Second)
+
+	var rule string
+	for _, line := range strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n") {
+		line = strings.TrimSpace(line)
+		upper := strings.ToUpper(line)
+		switch {
+		case line == "":
+		case strings.HasPrefix(upper, "DTSTART"):
+			t, err := parseICalTime(line[len("DTSTART"):], loc)
+			if err != nil {
+				return nil, errInvalidRRule
+			}
+			r.dtstart = t
+			r.loc = t.Location()
+		case strings.HasPrefix(upper, "RRULE:"):
+			rule = line[len("RRULE:"):]
+		default:
+			rule = line
+		}
+	}
+	if rule == "" {
+		return nil, errInvalidRRule
+	}
+
+	for _, part := range strings.Split(rule, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, errInvalidRRule
+		}
+		key, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+		var err error
+		switch key {
+		case "FREQ":
+			freq, ok := rruleFreqs[value]
+			if !ok {
+				return nil, errInvalidRRule
+			}
+			r.freq = freq
+		case "INTERVAL":
+			if r.interval, err = strconv.Atoi(value); err != nil || r.interval < 1 {
+				return nil, errInvalidRRule
+			}
+		case "COUNT":
+			if r.count, err = strconv.Atoi(value); err != nil || r.count < 1 {
+				return nil, errInvalidRRule
+			}
+		case "UNTIL":
+			if r.until, err = parseICalTime(":"+value, r.loc); err != nil {
+				return nil, errInvalidRRule
+			}
+			if len(value) == 8 {
+				// a date includes the whole day
+				r.until = r.until.AddDate(0, 0, 1).Add(-time.Second)
+			}
+		case "BYMONTH":
+			r.byMonth, err = parseRRuleInts(value, 1, 12, false)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseRRuleInts(value, 1, 31, true)
+		case "BYHOUR":
+			r.byHour, err = parseRRuleInts(value, 0, 23, false)
+		case "BYMINUTE":
+			r.byMinute, err = parseRRuleInts(value, 0, 59, false)
+		case "BYSECOND":
+			r.bySecond, err = parseRRuleInts(value, 0, 59, false)
+		case "BYSETPOS":
+			r.bySetPos, err = parseRRuleInts(value, 1, 366, true)
+		case "BYDAY":
+			r.byDay, err = parseRRuleDays(value)
+		case "WKST":
+			day, ok := rruleDays[value]
+			if !ok {
+				return nil, errInvalidRRule
+			}
+			r.wkst = day
+		default:
+			return nil, errInvalidRRule
+		}
+		if err != nil {
+			return nil, errInvalidRRule
+		}
+	}
+
+	if r.freq < 0 || (r.count > 0 && !r.until.IsZero()) {
+		return nil, errInvalidRRule
+	}
+	for _, d := range r.byDay {
+		if d.n != 0 && r.freq != freqMonthly && r.freq != freqYearly {
+			return nil, errInvalidRRule
+		}
+	}
+	if len(r.byMonthDay) > 0 && r.freq == freqWeekly {
+		return nil, errInvalidRRule
+	}
+	if len(r.bySetPos) > 0 && len(r.byMonth)+len(r.byMonthDay)+len(r.byDay)+len(r.byHour)+len(r.byMinute)+len(r.bySecond) == 0 {
+		return nil, errInvalidRRule
+	}
+	if !r.timeOfDayReachable() || !r.weekdayReachable() {
+		return nil, errImpossibleRRule
+	}
+	return r, nil
+}
+
+// timeOfDayReachable check whether a time of day allowed by BYHOUR, BYMINUTE
+// and BYSECOND is reachable by sub-daily periods. Periods advance by INTERVAL
+// units, so they only reach times of day congruent to dtstart modulo
+// gcd(INTERVAL, units per day).
+func (r *Recurrence) timeOfDayReachable() bool {
+	var unit, perDay int
+	switch r.freq {
+	case freqHourly:
+		unit, perDay = 3600, 24
+	case freqMinutely:
+		unit, perDay = 60, 24*60
+	case freqSecondly:
+		unit, perDay = 1, 24*60*60
+	default:
+		return true
+	}
+	g := gcd(r.interval, perDay)
+	s := r.dtstart
+	start := (s.Hour()*3600 + s.Minute()*60 + s.Second()) / unit
+	for _, h := range r.fieldValues(r.byHour, freqHourly, 23, s.Hour()) {
+		for _, m := range r.fieldValues(r.byMinute, freqMinutely, 59, s.Minute()) {
+			for _, sec := range r.fieldValues(r.bySecond, freqSecondly, 59, s.Second()) {
+				if ((h*3600+m*60+sec)/unit-start)%g == 0 {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// weekdayReachable check whether a BYDAY weekday is reachable by daily
+// periods, an INTERVAL multiple of 7 always lands on the weekday of dtstart.
+func (r *Recurrence) weekdayReachable() bool {
+	if r.freq != freqDaily || len(r.byDay) == 0 || r.interval%7 != 0 {
+		return true
+	}
+	for _, d := range r.byDay {
+		if d.day == r.dtstart.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldValues return the possible values of a time field, all values up to
+// max if the frequency is not coarser than the field and there is no BYxxx
+// part.
+func (r *Recurrence) fieldValues(by []int, freq, max, startValue int) []int {
+	if len(by) > 0 {
+		return by
+	}
+	if r.freq > freq {
+		return []int{startValue}
+	}
+	values := make([]int, max+1)
+	for i := range values {
+		values[i] = i
+	}
+	return values
+}
+
+// gcd return the greatest common divisor of a and b.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// parseICalTime parse the parameters and value of an iCalendar time
+// property, like ";TZID=Asia/Shanghai:20240101T090000" or ":20240101T010000Z".
+func parseICalTime(s string, loc *time.Location) (time.Time, error) {
+	i := strings.LastIndexByte(s, ':')
+	if i < 0 {
+		return time.Time{}, errInvalidRRule
+	}
+	params, value := s[:i], s[i+1:]
+	for _, param := range strings.Split(params, ";") {
+		if strings.HasPrefix(strings.ToUpper(param), "TZID=") {
+			l, err := time.LoadLocation(param[len("TZID="):])
+			if err != nil {
+				return time.Time{}, err
+			}
+			loc = l
+		}
+	}
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		t, err := time.Parse("20060102T150405Z", value)
+		return t.In(loc), err
+	case len(value) == 8:
+		return time.ParseInLocation("20060102", value, loc)
+	}
+	return time.ParseInLocation("20060102T150405", value, loc)
+}
+
+// parseRRuleInts parse a number list, negative numbers count from the end if
+// allowed.
+func parseRRuleInts(s string, min, max int, negative bool) ([]int, error) {
+	var nums []int
+	for _, field := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, errInvalidRRule
+		}
+		abs := n
+		if n < 0 && negative {
+			abs = -n
+		}
+		if abs < min || abs > max {
+			return nil, errInvalidRRule
+		}
+		nums = append(nums, n)
+	}
+	return nums, nil
+}
+
+// parseRRuleDays parse a BYDAY list like "MO,WE" or "1MO,-1FR".
+func parseRRuleDays(s string) ([]rruleWeekday, error) {
+	var days []rruleWeekday
+	for _, field := range strings.Split(s, ",") {
+		if len(field) < 2 {
+			return nil, errInvalidRRule
+		}
+		day, ok := rruleDays[field[len(field)-2:]]
+		if !ok {
+			return nil, errInvalidRRule
+		}
+		n := 0
+		if prefix := field[:len(field)-2]; prefix != "" {
+			var err error
+			if n, err = strconv.Atoi(prefix); err != nil || n == 0 || n > 53 || n < -53 {
+				return nil, errInvalidRRule
+			}
+		}
+		days = append(days, rruleWeekday{n, day})
+	}
+	return days, nil
+}
+
+// Start return the start time of recurrence.
+func (r *Recurrence) Start() time.Time {
+	return r.dtstart
+}
+
+// periodStart return the wall clock start of the k-th period in UTC.
+func (r *Recurrence) periodStart(k int) time.Time {
+	s := r.dtstart
+	n := k * r.interval
+	switch r.freq {
+	case freqYearly:
+		return time.Date(s.Year()+n, time.January, 1, 0, 0, 0, 0, time.UTC)
+	case freqMonthly:
+		return time.Date(s.Year(), s.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	case freqWeekly:
+		offset := (int(s.Weekday()) - int(r.wkst) + 7) % 7
+		return time.Date(s.Year(), s.Month(), s.Day()-offset+7*n, 0, 0, 0, 0, time.UTC)
+	case freqDaily:
+		return time.Date(s.Year(), s.Month(), s.Day()+n, 0, 0, 0, 0, time.UTC)
+	case freqHourly:
+		return time.Date(s.Year(), s.Month(), s.Day(), s.Hour()+n, 0, 0, 0, time.UTC)
+	case freqMinutely:
+		return time.Date(s.Year(), s.Month(), s.Day(), s.Hour(), s.Minute()+n, 0, 0, time.UTC)
+	}
+	return time.Date(s.Year(), s.Month(), s.Day(), s.Hour(), s.Minute(), s.Second()+n, 0, time.UTC)
+}
+
+// periodDays return the days of period starting from start.
+func (r *Recurrence) periodDays(start time.Time) []time.Time {
+	var end time.Time
+	switch r.freq {
+	case freqYearly:
+		end = start.AddDate(1, 0, 0)
+	case freqMonthly:
+		end = start.AddDate(0, 1, 0)
+	case freqWeekly:
+		end = start.AddDate(0, 0, 7)
+	default:
+		return []time.Time{time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)}
+	}
+	var days []time.Time
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+// dayMatches check whether the day matches BYMONTH, BYMONTHDAY and BYDAY.
+func (r *Recurrence) dayMatches(day time.Time) bool {
+	if len(r.byMonth) > 0 && !containsInt(r.byMonth, int(day.Month())) {
+		return false
+	}
+	if len(r.byMonthDay) > 0 {
+		lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		matched := false
+		for _, d := range r.byMonthDay {
+			if d == day.Day() || (d < 0 && lastDay+d+1 == day.Day()) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(r.byDay) > 0 {
+		matched := false
+		for _, d := range r.byDay {
+			if d.day == day.Weekday() && (d.n == 0 || r.weekdayOrdinalMatches(day, d.n)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	// without day rules, the day of dtstart is used
+	if len(r.byMonthDay) == 0 && len(r.byDay) == 0 {
+		switch r.freq {
+		case freqYearly:
+			if len(r.byMonth) == 0 && day.Month() != r.dtstart.Month() {
+				return false
+			}
+			return day.Day() == r.dtstart.Day()
+		case freqMonthly:
+			return day.Day() == r.dtstart.Day()
+		case freqWeekly:
+			return day.Weekday() == r.dtstart.Weekday()
+		}
+	}
+	return true
+}
+
+// weekdayOrdinalMatches check whether day is the n-th such weekday of its
+// month or year, negative n counts from the end.
+func (r *Recurrence) weekdayOrdinalMatches(day time.Time, n int) bool {
+	var first, last time.Time
+	if r.freq == freqMonthly || len(r.byMonth) > 0 {
+		first = time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+		last = first.AddDate(0, 1, -1)
+	} else {
+		first = time.Date(day.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+		last = time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+	if n > 0 {
+		return (day.YearDay()-first.YearDay())/7+1 == n
+	}
+	return (last.YearDay()-day.YearDay())/7+1 == -n
+}
+
+// timeValues return the values of a time field, the field limits the period
+// if the frequency is not coarser than it.
+func (r *Recurrence) timeValues(by []int, freq, periodValue, startValue int) []int {
+	if r.freq <= freq {
+		if len(by) > 0 && !containsInt(by, periodValue) {
+			return nil
+		}
+		return []int{periodValue}
+	}
+	if len(by) > 0 {
+		values := append([]int(nil), by...)
+		sort.Ints(values)
+		return values
+	}
+	return []int{startValue}
+}
+
+// periodOccurrences return the sorted occurrences of a period in wall clock
+// of UTC.
+func (r *Recurrence) periodOccurrences(start time.Time) []time.Time {
+	var times []time.Time
+	hours := r.timeValues(r.byHour, freqHourly, start.Hour(), r.dtstart.Hour())
+	minutes := r.timeValues(r.byMinute, freqMinutely, start.Minute(), r.dtstart.Minute())
+	seconds := r.timeValues(r.bySecond, freqSecondly, start.Second(), r.dtstart.Second())
+	for _, day := range r.periodDays(start) {
+		if !r.dayMatches(day) {
+			continue
+		}
+		for _, h := range hours {
+			for _, m := range minutes {
+				for _, sec := range seconds {
+					times = append(times, time.Date(day.Year(), day.Month(), day.Day(), h, m, sec, 0, time.UTC))
+				}
+			}
+		}
+	}
+
+	if len(r.bySetPos) > 0 {
+		var selected []time.Time
+		for _, pos := range r.bySetPos {
+			i := pos - 1
+			if pos < 0 {
+				i = len(times) + pos
+			}
+			if i >= 0 && i < len(times) {
+				selected = append(selected, times[i])
+			}
+		}
+		sort.Slice(selected, func(i, j int) bool { return selected[i].Before(selected[j]) })
+		times = selected
+	}
+	return times
+}
+
+// NextN return the next n occurrences after t, limited by COUNT and UNTIL.
+// Less occurrences are returned if they can not be found within the search
+// limit.
+func (r *Recurrence) NextN(t time.Time, n int) []time.Time {
+	times, _ := r.nextN(t, n, time.Time{})
+	return times
+}
+
+// nextN return the next n occurrences after t and not after horizon unless
+// it is zero, complete is false if the search stopped at the period limit.
+func (r *Recurrence) nextN(t time.Time, n int, horizon time.Time) (times []time.Time, complete bool) {
+	maxPeriods := rruleMaxPeriods
+	if r.freq < freqDaily {
+		maxPeriods = rruleMaxSubDailyPeriods
+	}
+	first := r.firstPeriod(t)
+	limit := r.dtstart
+	if t.After(limit) {
+		limit = t
+	}
+	limit = limit.AddDate(rruleSearchYears, 0, 0)
+	emitted, searched := 0, 0
+	for k := first; len(times) < n; k++ {
+		if searched++; searched > maxPeriods {
+			return times, false
+		}
+		start := r.periodStart(k)
+		local := time.Date(start.Year(), start.Month(), start.Day(), start.Hour(), start.Minute(), start.Second(), 0, r.loc)
+		if len(times) == 0 && k-first > rruleSearchPeriods && local.After(limit) {
+			return times, true
+		}
+		if !r.until.IsZero() && local.After(r.until) {
+			return times, true
+		}
+		if !horizon.IsZero() && local.After(horizon) {
+			return times, true
+		}
+		for _, wall := range r.periodOccurrences(start) {
+			occur := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, r.loc)
+			if occur.Before(r.dtstart) {
+				continue
+			}
+			if !r.until.IsZero() && occur.After(r.until) {
+				return times, true
+			}
+			emitted++
+			if r.count > 0 && emitted > r.count {
+				return times, true
+			}
+			if occur.After(t) {
+				times = append(times, occur)
+				if len(times) == n {
+					break
+				}
+			}
+		}
+		if r.freq < freqDaily {
+			k = r.skipUnmatched(k)
+		}
+	}
+	return times, true
+}
+
+// firstPeriod return the index of a period not later than the one containing
+// t. Rules with COUNT always start from the first period to count
+// occurrences.
+func (r *Recurrence) firstPeriod(t time.Time) int {
+	if r.count > 0 || !t.After(r.dtstart) {
+		return 0
+	}
+	t = t.In(r.loc)
+	s := r.dtstart
+	var k int
+	switch r.freq {
+	case freqYearly:
+		k = (t.Year() - s.Year()) / r.interval
+	case freqMonthly:
+		k = ((t.Year()-s.Year())*12 + int(t.Month()) - int(s.Month())) / r.interval
+	default:
+		wallT := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+		step := r.periodStart(1).Sub(r.periodStart(0))
+		k = int(wallT.Sub(r.periodStart(0)) / step)
+	}
+	if k > 1 {
+		return k - 1
+	}
+	return 0
+}
+
+// skipUnmatched return the index of the last period before the next day,
+// hour, minute or second which may match if the k-th period does not match,
+// so that sub-daily rules skip unmatched periods quickly.
+func (r *Recurrence) skipUnmatched(k int) int {
+	start := r.periodStart(k)
+	y, mon, d := start.Date()
+	h, m, sec := start.Clock()
+	var next time.Time
+	switch {
+	case !r.dayMatches(start):
+		next = time.Date(y, mon, d+1, 0, 0, 0, 0, time.UTC)
+	case r.freq < freqHourly && len(r.byHour) > 0 && !containsInt(r.byHour, h):
+		if v, ok := nextInt(r.byHour, h); ok {
+			next = time.Date(y, mon, d, v, 0, 0, 0, time.UTC)
+		} else {
+			next = time.Date(y, mon, d+1, 0, 0, 0, 0, time.UTC)
+		}
+	case r.freq < freqMinutely && len(r.byMinute) > 0 && !containsInt(r.byMinute, m):
+		if v, ok := nextInt(r.byMinute, m); ok {
+			next = time.Date(y, mon, d, h, v, 0, 0, time.UTC)
+		} else {
+			next = time.Date(y, mon, d, h+1, 0, 0, 0, time.UTC)
+		}
+	case r.freq == freqSecondly && len(r.bySecond) > 0 && !containsInt(r.bySecond, sec):
+		if v, ok := nextInt(r.bySecond, sec); ok {
+			next = time.Date(y, mon, d, h, m, v, 0, time.UTC)
+		} else {
+			next = time.Date(y, mon, d, h, m+1, 0, 0, time.UTC)
+		}
+	default:
+		return k
+	}
+	step := r.periodStart(k + 1).Sub(start)
+	return k + int((next.Sub(start)-1)/step)
+}
+
+// Next return the first occurrence after t, zero time if there is none.
+func (r *Recurrence) Next(t time.Time) time.Time {
+	times := r.NextN(t, 1)
+	if len(times) == 0 {
+		return time.Time{}
+	}
+	return times[0]
+}
+
+// Preview return the next n occurrences from now.
+func (r *Recurrence) Preview(n int) []time.Time {
+	return r.NextN(now(), n)
+}
+
+// MinInterval return the minimum interval between two consecutive
+// occurrences within the first occurrences of the first years, zero if
+// there are less than two.
+func (r *Recurrence) MinInterval() time.Duration {
+	d, _ := r.minInterval()
+	return d
+}
+
+// minInterval is like MinInterval, complete is false if the search stopped
+// at the period limit.
+func (r *Recurrence) minInterval() (best time.Duration, complete bool) {
+	horizon := r.dtstart.AddDate(rruleSearchYears, 0, 0)
+	times, complete := r.nextN(r.dtstart.Add(-time.Second), rruleIntervalSamples, horizon)
+	for i := 1; i < len(times); i++ {
+		if gap := times[i].Sub(times[i-1]); i == 1 || gap < best {
+			best = gap
+		}
+	}
+	return best, complete
+}
+
+// nextInt return the smallest number in nums larger than n.
+func nextInt(nums []int, n int) (int, bool) {
+	next, found := 0, false
+	for _, v := range nums {
+		if v > n && (!found || v < next) {
+			next, found = v, true
+		}
+	}
+	return next, found
+}
+
+// containsInt check whether n is in nums.
+func containsInt(nums []int, n int) bool {
+	for _, v := range nums {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+// Run make the filter running.
+func (f *RRuleFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var rule *Recurrence
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		r, err := ParseRRule(val, now(), timeLoc)
+		if err == errImpossibleRRule {
+			return nil, NewError(ErrorInvalidParam, paramName, "ImpossibleSchedule")
+		}
+		if err != nil {
+			goto parse_error
+		}
+		rule = r
+	case *Recurrence:
+		rule = val
+	default:
+		goto parse_error
+	}
+
+	if times, complete := rule.nextN(rule.dtstart.Add(-time.Second), 1, time.Time{}); len(times) == 0 {
+		if !complete {
+			return nil, NewError(ErrorInvalidParam, paramName, "ScheduleTooSparse")
+		}
+		return nil, NewError(ErrorInvalidParam, paramName, "ImpossibleSchedule")
+	}
+	if f.minInterval > 0 {
+		d, complete := rule.minInterval()
+		if !complete {
+			return nil, NewError(ErrorInvalidParam, paramName, "ScheduleTooSparse")
+		}
+		if d > 0 && d < f.minInterval {
+			return nil, NewError(ErrorInvalidParam, paramName, "IntervalTooShort")
+		}
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, rule); err != nil {
+			return nil, err
+		}
+	}
+
+	return rule, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotRRule")
+}
@@ -0,0 +1,120 @@
+package filter
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		rule string
+		err  error
+	}{
+		{"FREQ=WEEKLY;BYDAY=MO,WE", nil},
+		{"RRULE:FREQ=MONTHLY;BYDAY=-1FR", nil},
+		{"FREQ=DAILY;COUNT=3;UNTIL=20250101", errInvalidRRule},
+		{"FREQ=WEEKLY;BYDAY=1MO", errInvalidRRule},
+		{"FREQ=DAILY;INTERVAL=0", errInvalidRRule},
+		{"FREQ=FORTNIGHTLY", errInvalidRRule},
+		{"BYDAY=MO", errInvalidRRule},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=SECONDLY;INTERVAL=2;BYSECOND=1", errImpossibleRRule},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=SECONDLY;INTERVAL=2;BYSECOND=1,2", nil},
+		{"DTSTART:20240101T000001\nRRULE:FREQ=SECONDLY;INTERVAL=2;BYSECOND=1", nil},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=MINUTELY;INTERVAL=2;BYMINUTE=1", errImpossibleRRule},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=MINUTELY;INTERVAL=7;BYMINUTE=1", nil},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=HOURLY;INTERVAL=6;BYHOUR=3", errImpossibleRRule},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=SECONDLY;INTERVAL=120;BYMINUTE=1", errImpossibleRRule},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=DAILY;INTERVAL=14;BYDAY=TU", errImpossibleRRule},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=DAILY;INTERVAL=14;BYDAY=MO", nil},
+	}
+	for _, tt := range tests {
+		if _, err := ParseRRule(tt.rule, time.Now(), timeLoc); err != tt.err {
+			t.Errorf("ParseRRule(%q) error = %v, want %v", tt.rule, err, tt.err)
+		}
+	}
+}
+
+func TestRecurrenceNextN(t *testing.T) {
+	tests := []struct {
+		rule string
+		want []string
+	}{
+		{"DTSTART:20240101T090000\nRRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=3",
+			[]string{"2024-01-01 09:00:00", "2024-01-03 09:00:00", "2024-01-08 09:00:00"}},
+		{"DTSTART:20240131T100000\nRRULE:FREQ=MONTHLY",
+			[]string{"2024-01-31 10:00:00", "2024-03-31 10:00:00", "2024-05-31 10:00:00"}},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=MONTHLY;BYDAY=-1FR",
+			[]string{"2024-01-26 00:00:00", "2024-02-23 00:00:00", "2024-03-29 00:00:00"}},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			[]string{"2024-01-31 00:00:00", "2024-02-29 00:00:00", "2024-03-29 00:00:00"}},
+		{"DTSTART:20240101T000001\nRRULE:FREQ=SECONDLY;INTERVAL=2;BYSECOND=1",
+			[]string{"2024-01-01 00:00:01", "2024-01-01 00:01:01", "2024-01-01 00:02:01"}},
+		{"DTSTART:20240229T000000\nRRULE:FREQ=YEARLY;UNTIL=20300101",
+			[]string{"2024-02-29 00:00:00", "2028-02-29 00:00:00"}},
+	}
+	for _, tt := range tests {
+		r, err := ParseRRule(tt.rule, time.Now(), timeLoc)
+		if err != nil {
+			t.Errorf("ParseRRule(%q) error = %v", tt.rule, err)
+			continue
+		}
+		got := r.NextN(r.Start().Add(-time.Second), 3)
+		if format(got) != fmt.Sprint(tt.want) {
+			t.Errorf("NextN(%q) = %v, want %v", tt.rule, format(got), tt.want)
+		}
+	}
+}
+
+func TestRRuleFilter(t *testing.T) {
+	testRun(t, []runCase{
+		{RRule(), "DTSTART:20240101T000000\nRRULE:FREQ=SECONDLY;INTERVAL=2;BYSECOND=1", "", "ImpossibleSchedule"},
+		{RRule(), "DTSTART:20240101T000000\nRRULE:FREQ=MINUTELY;INTERVAL=2;BYMINUTE=1", "", "ImpossibleSchedule"},
+		{RRule(), "DTSTART:20240101T000000\nRRULE:FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", "", "ImpossibleSchedule"},
+		{RRule(), "DTSTART:20240101T000000\nRRULE:FREQ=SECONDLY;BYMONTH=2;BYMONTHDAY=30", "", "ImpossibleSchedule"},
+		// mondays at midnight never land on the 7 seconds grid from a tuesday
+		{RRule(), "DTSTART:20240102T000000\nRRULE:FREQ=SECONDLY;INTERVAL=7;BYHOUR=0;BYMINUTE=0;BYSECOND=0;BYDAY=MO", "", "ImpossibleSchedule"},
+		{RRule(), "FREQ=DAILY;BYHOUR=25", "", "NotRRule"},
+		{RRule().MinInterval(time.Hour), "FREQ=MINUTELY;INTERVAL=30", "", "IntervalTooShort"},
+	})
+
+	start := time.Now()
+	f := RRule().MinInterval(time.Second)
+	for _, rule := range []string{
+		"DTSTART:20240101T000000\nRRULE:FREQ=SECONDLY;BYMONTH=12;BYMONTHDAY=31;BYHOUR=23;BYMINUTE=59;BYSECOND=59",
+		"DTSTART:20240101T000000\nRRULE:FREQ=SECONDLY;BYMONTH=2;BYMONTHDAY=29;BYSECOND=7",
+		"DTSTART:20240101T000000\nRRULE:FREQ=SECONDLY;INTERVAL=2;BYSECOND=1",
+		"DTSTART:20240101T000000\nRRULE:FREQ=SECONDLY;BYMONTH=2;BYMONTHDAY=30",
+		"DTSTART:20240101T000000\nRRULE:FREQ=MINUTELY;BYMONTH=2;BYMONTHDAY=30",
+		"DTSTART:20240102T000000\nRRULE:FREQ=SECONDLY;INTERVAL=7;BYHOUR=0;BYMINUTE=0;BYSECOND=0;BYDAY=MO",
+	} {
+		f.Run("p", rule)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("sparse rules took %v", d)
+	}
+}
+
+func TestRecurrenceMinInterval(t *testing.T) {
+	tests := []struct {
+		rule string
+		want time.Duration
+	}{
+		{"DTSTART:20240101T000000\nRRULE:FREQ=MINUTELY;INTERVAL=30", 30 * time.Minute},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=WEEKLY;BYDAY=MO,WE", 2 * 24 * time.Hour},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=SECONDLY;BYMONTH=12;BYMONTHDAY=31;BYHOUR=23;BYMINUTE=59;BYSECOND=59", 365 * 24 * time.Hour},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=SECONDLY;BYMONTH=2;BYMONTHDAY=29;BYSECOND=7", time.Minute},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=HOURLY;INTERVAL=5;BYHOUR=0", 5 * 24 * time.Hour},
+		{"DTSTART:20240101T000000\nRRULE:FREQ=DAILY;COUNT=1", 0},
+	}
+	for _, tt := range tests {
+		r, err := ParseRRule(tt.rule, time.Now(), timeLoc)
+		if err != nil {
+			t.Errorf("ParseRRule(%q) error = %v", tt.rule, err)
+			continue
+		}
+		if d, complete := r.minInterval(); d != tt.want || !complete {
+			t.Errorf("%q minInterval() = %v, %v, want %v, true", tt.rule, d, complete, tt.want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package filter
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WeeklySlot is a time of day range on a weekday, a slot crossing midnight
+// ends on the next day.
+type WeeklySlot struct {
+	Weekday time.Weekday
+	Range   ClockRange
+}
+
+type WeeklyScheduleFilter struct {
+	minSlot       time.Duration
+	allowOverlap  bool
+	validators    []WeeklyScheduleValidator
+	allowVals     allowList
+	entrySep      string
+	slotDelimiter string
+}
+
+type WeeklyScheduleValidator func(paramName string, paramValue []*WeeklySlot) *Error
+
+var errInvalidSchedule = errors.New("invalid weekly schedule")
+
+const weekDuration = 7 * 24 * time.Hour
+
+// WeeklySchedule return a weekly schedule filter, it accepts entries of
+// weekdays and time of day ranges separated by ";", eg:
+// "mon-fri 09:00-12:00,13:00-18:00; sat 22:00-02:00", and output []*WeeklySlot.
+// Overlapping slots are rejected by default.
+func WeeklySchedule() *WeeklyScheduleFilter {
+	f := new(WeeklyScheduleFilter)
+	f.entrySep = ";"
+	f.slotDelimiter = ","
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *WeeklyScheduleFilter) Allow(vals ...string) *WeeklyScheduleFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *WeeklyScheduleFilter) AllowSpecial(special Special, vals ...string) *WeeklyScheduleFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *WeeklyScheduleFilter) AllowSpecialFold(special Special, vals ...string) *WeeklyScheduleFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// MinSlot set the minimum length of each slot.
+func (f *WeeklyScheduleFilter) MinSlot(d time.Duration) *WeeklyScheduleFilter {
+	f.minSlot = d
+	return f
+}
+
+// AllowOverlap allow slots to overlap each other.
+func (f *WeeklyScheduleFilter) AllowOverlap() *WeeklyScheduleFilter {
+	f.allowOverlap = true
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *WeeklyScheduleFilter) AddValidator(validator WeeklyScheduleValidator) *WeeklyScheduleFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// parseSchedule parse schedule entries like "mon,wed 09:00-18:00".
+func (f *WeeklyScheduleFilter) parseSchedule(entries []string) ([]*WeeklySlot, error) {
+	var slots []*WeeklySlot
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		i := strings.IndexAny(entry, " \t")
+		if i < 0 {
+			return nil, errInvalidSchedule
+		}
+		days, err := parseWeekdays(strings.Split(entry[:i], ","))
+		if err != nil {
+			return nil, errInvalidSchedule
+		}
+		for _, s := range strings.Split(entry[i+1:], f.slotDelimiter) {
+			r, err := ParseClockRange(strings.TrimSpace(s))
+			if err != nil {
+				return nil, errInvalidSchedule
+			}
+			for _, day := range days {
+				slots = append(slots, &WeeklySlot{day, *r})
+			}
+		}
+	}
+	return slots, nil
+}
+
+// slotsOverlap check whether any two slots overlap, slots are placed on a
+// week starting from monday and the end of week wraps to its start.
+func slotsOverlap(slots []*WeeklySlot) bool {
+	type interval struct{ start, end time.Duration }
+	var intervals []interval
+	for _, slot := range slots {
+		start := time.Duration((slot.Weekday+6)%7)*24*time.Hour + time.Duration(slot.Range.Start)
+		end := start + slot.Range.Length()
+		if end > weekDuration {
+			intervals = append(intervals, interval{start, weekDuration}, interval{0, end - weekDuration})
+		} else {
+			intervals = append(intervals, interval{start, end})
+		}
+	}
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].start < intervals[j].start })
+	for i := 1; i < len(intervals); i++ {
+		if intervals[i].start < intervals[i-1].end {
+			return true
+		}
+	}
+	return false
+}
+
+// Run make the filter running.
+func (f *WeeklyScheduleFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var slots []*WeeklySlot
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		var err error
+		slots, err = f.parseSchedule(strings.Split(val, f.entrySep))
+		if err != nil {
+			goto parse_error
+		}
+	case []string:
+		var err error
+		slots, err = f.parseSchedule(val)
+		if err != nil {
+			goto parse_error
+		}
+	case []*WeeklySlot:
+		slots = val
+	default:
+		goto parse_error
+	}
+
+	for _, slot := range slots {
+		if slot.Range.Length() < f.minSlot {
+			return nil, NewError(ErrorInvalidParam, paramName, "SlotTooShort")
+		}
+	}
+	if !f.allowOverlap && slotsOverlap(slots) {
+		return nil, NewError(ErrorInvalidParam, paramName, "SlotsOverlap")
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, slots); err != nil {
+			return nil, err
+		}
+	}
+
+	return slots, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotWeeklySchedule")
+}
@@ -0,0 +1,271 @@
+package filter
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/go-apibox/types"
+)
+
+type BigIntSetFilter struct {
+	base       int
+	strict     strictMode
+	delimiter  string
+	minCount   int
+	maxCount   int
+	validators []BigIntSetValidator
+	allowVals  allowList
+}
+
+type BigIntSetValidator func(paramName string, paramValue []*big.Int) *Error
+
+// BigIntSet return a arbitrary-precision int set filter.
+func BigIntSet() *BigIntSetFilter {
+	f := new(BigIntSetFilter)
+	f.base = 10
+	f.delimiter = ","
+	f.minCount = 0
+	f.maxCount = types.MaxInt
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *BigIntSetFilter) Allow(vals ...string) *BigIntSetFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *BigIntSetFilter) AllowSpecial(special Special, vals ...string) *BigIntSetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *BigIntSetFilter) AllowSpecialFold(special Special, vals ...string) *BigIntSetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Base set the base of int.
+// BigIntSetFilter interprets a string s in the given base (2 to 62) and returns
+// the corresponding value i. If base == 0, the base is implied by the
+// string's prefix: base 16 for "0x", base 8 for "0", and base 10 otherwise.
+func (f *BigIntSetFilter) Base(base int) *BigIntSetFilter {
+	f.base = base
+	return f
+}
+
+// Delimiter set the delimiter of set string.
+func (f *BigIntSetFilter) Delimiter(delimiter string) *BigIntSetFilter {
+	f.delimiter = delimiter
+	return f
+}
+
+// MinCount set the max item count of set.
+func (f *BigIntSetFilter) MinCount(count int) *BigIntSetFilter {
+	f.minCount = count
+	return f
+}
+
+// MaxCount set the max item count of set.
+func (f *BigIntSetFilter) MaxCount(count int) *BigIntSetFilter {
+	f.maxCount = count
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *BigIntSetFilter) Strict() *BigIntSetFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *BigIntSetFilter) Lenient() *BigIntSetFilter {
+	f.strict = strictOff
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *BigIntSetFilter) AddValidator(validator BigIntSetValidator) *BigIntSetFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// ItemMin valid whether item value of set is not smaller than specified decimal value.
+func (f *BigIntSetFilter) ItemMin(val string) *BigIntSetFilter {
+	i, parseErr := parseBigInt(val, 10)
+	f.AddValidator(func(paramName string, paramValue []*big.Int) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		for _, v := range paramValue {
+			if v.Cmp(i) < 0 {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooSmall")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemMax valid whether item value of set is not larger than specified decimal value.
+func (f *BigIntSetFilter) ItemMax(val string) *BigIntSetFilter {
+	i, parseErr := parseBigInt(val, 10)
+	f.AddValidator(func(paramName string, paramValue []*big.Int) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		for _, v := range paramValue {
+			if v.Cmp(i) > 0 {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLarge")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemBetween valid whether item value of set is in the specified range.
+func (f *BigIntSetFilter) ItemBetween(min, max string) *BigIntSetFilter {
+	minVal, minErr := parseBigInt(min, 10)
+	maxVal, maxErr := parseBigInt(max, 10)
+	f.AddValidator(func(paramName string, paramValue []*big.Int) *Error {
+		if minErr != nil || maxErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		for _, v := range paramValue {
+			if v.Cmp(minVal) < 0 {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooSmall")
+			}
+			if v.Cmp(maxVal) > 0 {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLarge")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemIn valid item value of set should in the specified set.
+func (f *BigIntSetFilter) ItemIn(set []string) *BigIntSetFilter {
+	intSet, parseErr := parseBigInts(set, 10)
+	f.AddValidator(func(paramName string, paramValue []*big.Int) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		for _, item := range paramValue {
+			itemFound := false
+			for _, v := range intSet {
+				if v.Cmp(item) == 0 {
+					itemFound = true
+					break
+				}
+			}
+			if !itemFound {
+				return NewError(ErrorInvalidParam, paramName, "ItemNotInSet")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemMaxBitLen valid whether the bit length of absolute item value is not
+// larger than the specified value.
+func (f *BigIntSetFilter) ItemMaxBitLen(bits int) *BigIntSetFilter {
+	f.AddValidator(func(paramName string, paramValue []*big.Int) *Error {
+		for _, v := range paramValue {
+			if v.BitLen() > bits {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooManyBits")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// Run make the filter running.
+func (f *BigIntSetFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var intVals []*big.Int
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if val != "" {
+			fields := strings.Split(val, f.delimiter)
+			for _, field := range fields {
+				if word := f.strict.check(field, f.base, false); word != "" {
+					return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+				}
+				field = strings.Trim(field, " \t\r\n")
+				v, err := parseBigInt(field, f.base)
+				if err != nil {
+					goto parse_error
+				}
+				intVals = append(intVals, v)
+			}
+		} else {
+			intVals = []*big.Int{}
+		}
+	case []string:
+		fields := val
+		for _, field := range fields {
+			if word := f.strict.check(field, f.base, false); word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
+			field = strings.Trim(field, " \t\r\n")
+			v, err := parseBigInt(field, f.base)
+			if err != nil {
+				goto parse_error
+			}
+			intVals = append(intVals, v)
+		}
+	case []*big.Int:
+		intVals = val
+	default:
+		items, ok := coerceItems(val)
+		if !ok {
+			goto parse_error
+		}
+		intVals = make([]*big.Int, 0, len(items))
+		for _, item := range items {
+			v, word, ok := coerceBigInt(item)
+			if !ok {
+				goto parse_error
+			}
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
+			intVals = append(intVals, v)
+		}
+	}
+
+	if len(intVals) < f.minCount {
+		return nil, NewError(ErrorInvalidParam, paramName, "TooFew")
+	}
+	if len(intVals) > f.maxCount {
+		return nil, NewError(ErrorInvalidParam, paramName, "TooMany")
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, intVals); err != nil {
+			return nil, err
+		}
+	}
+
+	return intVals, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotBigIntSet")
+}
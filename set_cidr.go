@@ -12,7 +12,7 @@ type CIDRSetFilter struct {
 	minCount   int
 	maxCount   int
 	validators []CIDRSetValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type CIDRSetValidator func(paramName string, paramValue []*CIDRAddr) *Error
@@ -26,9 +26,24 @@ func CIDRSet() *CIDRSetFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *CIDRSetFilter) Allow(vals ...string) *CIDRSetFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *CIDRSetFilter) AllowSpecial(special Special, vals ...string) *CIDRSetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *CIDRSetFilter) AllowSpecialFold(special Special, vals ...string) *CIDRSetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -94,10 +109,8 @@ func (f *CIDRSetFilter) Run(paramName string, paramValue interface{}) (interface
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		if val != "" {
 			fields := strings.Split(val, f.delimiter)
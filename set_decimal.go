@@ -0,0 +1,344 @@
+package filter
+
+import (
+	"strings"
+
+	"github.com/go-apibox/types"
+)
+
+type DecimalSetFilter struct {
+	strict     strictMode
+	delimiter  string
+	minCount   int
+	maxCount   int
+	rounding   bool
+	roundScale int
+	roundMode  RoundingMode
+	validators []DecimalSetValidator
+	allowVals  allowList
+}
+
+type DecimalSetValidator func(paramName string, paramValue []*BigDecimal) *Error
+
+// DecimalSet return a arbitrary-precision decimal set filter.
+func DecimalSet() *DecimalSetFilter {
+	f := new(DecimalSetFilter)
+	f.delimiter = ","
+	f.minCount = 0
+	f.maxCount = types.MaxInt
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *DecimalSetFilter) Allow(vals ...string) *DecimalSetFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *DecimalSetFilter) AllowSpecial(special Special, vals ...string) *DecimalSetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *DecimalSetFilter) AllowSpecialFold(special Special, vals ...string) *DecimalSetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Delimiter set the delimiter of set string.
+func (f *DecimalSetFilter) Delimiter(delimiter string) *DecimalSetFilter {
+	f.delimiter = delimiter
+	return f
+}
+
+// MinCount set the max item count of set.
+func (f *DecimalSetFilter) MinCount(count int) *DecimalSetFilter {
+	f.minCount = count
+	return f
+}
+
+// MaxCount set the max item count of set.
+func (f *DecimalSetFilter) MaxCount(count int) *DecimalSetFilter {
+	f.maxCount = count
+	return f
+}
+
+// Round round the output items to the specified scale with rounding mode.
+// Rounding is applied after validation.
+func (f *DecimalSetFilter) Round(scale int, mode RoundingMode) *DecimalSetFilter {
+	f.rounding = true
+	f.roundScale = scale
+	f.roundMode = mode
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *DecimalSetFilter) Strict() *DecimalSetFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *DecimalSetFilter) Lenient() *DecimalSetFilter {
+	f.strict = strictOff
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *DecimalSetFilter) AddValidator(validator DecimalSetValidator) *DecimalSetFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// ItemPrecision valid whether item value of set fits in a SQL
+// DECIMAL(precision, scale) column.
+func (f *DecimalSetFilter) ItemPrecision(precision, scale int) *DecimalSetFilter {
+	f.AddValidator(func(paramName string, paramValue []*BigDecimal) *Error {
+		for _, v := range paramValue {
+			if v.DecimalPlaces() > scale {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooManyDecimalPlaces")
+			}
+			if v.IntDigits() > precision-scale {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooManyDigits")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemScale valid whether decimal places of item value is not larger than
+// the specified value.
+func (f *DecimalSetFilter) ItemScale(places int) *DecimalSetFilter {
+	f.AddValidator(func(paramName string, paramValue []*BigDecimal) *Error {
+		for _, v := range paramValue {
+			if v.DecimalPlaces() > places {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooManyDecimalPlaces")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemMaxDigits valid whether the total count of digits of item value is not
+// larger than the specified value.
+func (f *DecimalSetFilter) ItemMaxDigits(count int) *DecimalSetFilter {
+	f.AddValidator(func(paramName string, paramValue []*BigDecimal) *Error {
+		for _, v := range paramValue {
+			if v.Digits() > count {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooManyDigits")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemMin valid whether item value of set is not smaller than specified value.
+func (f *DecimalSetFilter) ItemMin(val string) *DecimalSetFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue []*BigDecimal) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		for _, v := range paramValue {
+			if v.Cmp(d) < 0 {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooSmall")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemMax valid whether item value of set is not larger than specified value.
+func (f *DecimalSetFilter) ItemMax(val string) *DecimalSetFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue []*BigDecimal) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		for _, v := range paramValue {
+			if v.Cmp(d) > 0 {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLarge")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemLargerThan valid whether item value of set is larger than the specified value.
+func (f *DecimalSetFilter) ItemLargerThan(val string) *DecimalSetFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue []*BigDecimal) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		for _, v := range paramValue {
+			if v.Cmp(d) <= 0 {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooSmall")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemSmallerThan valid whether item value of set is smaller than the specified value.
+func (f *DecimalSetFilter) ItemSmallerThan(val string) *DecimalSetFilter {
+	d, parseErr := ParseBigDecimal(val)
+	f.AddValidator(func(paramName string, paramValue []*BigDecimal) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		for _, v := range paramValue {
+			if v.Cmp(d) >= 0 {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLarge")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemBetween valid whether item value of set is in the specified range.
+func (f *DecimalSetFilter) ItemBetween(min, max string) *DecimalSetFilter {
+	minVal, minErr := ParseBigDecimal(min)
+	maxVal, maxErr := ParseBigDecimal(max)
+	f.AddValidator(func(paramName string, paramValue []*BigDecimal) *Error {
+		if minErr != nil || maxErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		for _, v := range paramValue {
+			if v.Cmp(minVal) < 0 {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooSmall")
+			}
+			if v.Cmp(maxVal) > 0 {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLarge")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemIn valid item value of set should in the specified set.
+func (f *DecimalSetFilter) ItemIn(set []string) *DecimalSetFilter {
+	decSet, parseErr := parseDecimals(set)
+	f.AddValidator(func(paramName string, paramValue []*BigDecimal) *Error {
+		if parseErr != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		for _, item := range paramValue {
+			itemFound := false
+			for _, v := range decSet {
+				if v.Cmp(item) == 0 {
+					itemFound = true
+					break
+				}
+			}
+			if !itemFound {
+				return NewError(ErrorInvalidParam, paramName, "ItemNotInSet")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// Run make the filter running.
+func (f *DecimalSetFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var decVals []*BigDecimal
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if val != "" {
+			fields := strings.Split(val, f.delimiter)
+			for _, field := range fields {
+				if word := f.strict.check(field, 10, true); word != "" {
+					return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+				}
+				field = strings.Trim(field, " \t\r\n")
+				v, err := ParseBigDecimal(field)
+				if err != nil {
+					goto parse_error
+				}
+				decVals = append(decVals, v)
+			}
+		} else {
+			decVals = []*BigDecimal{}
+		}
+	case []string:
+		fields := val
+		for _, field := range fields {
+			if word := f.strict.check(field, 10, true); word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
+			field = strings.Trim(field, " \t\r\n")
+			v, err := ParseBigDecimal(field)
+			if err != nil {
+				goto parse_error
+			}
+			decVals = append(decVals, v)
+		}
+	case []*BigDecimal:
+		decVals = val
+	default:
+		items, ok := coerceItems(val)
+		if !ok {
+			goto parse_error
+		}
+		decVals = make([]*BigDecimal, 0, len(items))
+		for _, item := range items {
+			v, word, ok := coerceDecimal(item)
+			if !ok {
+				goto parse_error
+			}
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
+			decVals = append(decVals, v)
+		}
+	}
+
+	if len(decVals) < f.minCount {
+		return nil, NewError(ErrorInvalidParam, paramName, "TooFew")
+	}
+	if len(decVals) > f.maxCount {
+		return nil, NewError(ErrorInvalidParam, paramName, "TooMany")
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, decVals); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.rounding {
+		rounded := make([]*BigDecimal, len(decVals))
+		for i, v := range decVals {
+			rounded[i] = v.Round(f.roundScale, f.roundMode)
+		}
+		decVals = rounded
+	}
+
+	return decVals, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotDecimalSet")
+}
@@ -13,7 +13,7 @@ type EmailSetFilter struct {
 	minCount   int
 	maxCount   int
 	validators []EmailSetValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type EmailSetValidator func(paramName string, paramValue []string) *Error
@@ -28,9 +28,24 @@ func EmailSet() *EmailSetFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *EmailSetFilter) Allow(vals ...string) *EmailSetFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *EmailSetFilter) AllowSpecial(special Special, vals ...string) *EmailSetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *EmailSetFilter) AllowSpecialFold(special Special, vals ...string) *EmailSetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -167,10 +182,8 @@ func (f *EmailSetFilter) Run(paramName string, paramValue interface{}) (interfac
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		if val != "" {
 			strVals = strings.Split(val, f.delimiter)
@@ -0,0 +1,156 @@
+package filter
+
+import (
+	"strings"
+
+	"github.com/go-apibox/types"
+)
+
+type EnumSetFilter struct {
+	members      enumMembers
+	onDeprecated EnumDeprecatedHandler
+	delimiter    string
+	minCount     int
+	maxCount     int
+	validators   []EnumSetValidator
+	allowVals    allowList
+}
+
+type EnumSetValidator func(paramName string, paramValue []interface{}) *Error
+
+// EnumSet return a enum set filter which maps external names to internal values.
+func EnumSet() *EnumSetFilter {
+	f := new(EnumSetFilter)
+	f.delimiter = ","
+	f.minCount = 0
+	f.maxCount = types.MaxInt
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *EnumSetFilter) Allow(vals ...string) *EnumSetFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *EnumSetFilter) AllowSpecial(special Special, vals ...string) *EnumSetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *EnumSetFilter) AllowSpecialFold(special Special, vals ...string) *EnumSetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Member add a enum member, the name and aliases are mapped to value.
+func (f *EnumSetFilter) Member(name string, value interface{}, aliases ...string) *EnumSetFilter {
+	f.members.add(name, value, aliases, false)
+	return f
+}
+
+// DeprecatedMember add a deprecated enum member, it still validates but
+// OnDeprecated handler will be called when it is used.
+func (f *EnumSetFilter) DeprecatedMember(name string, value interface{}, aliases ...string) *EnumSetFilter {
+	f.members.add(name, value, aliases, true)
+	return f
+}
+
+// IgnoreCase match the member names and aliases case insensitively.
+func (f *EnumSetFilter) IgnoreCase() *EnumSetFilter {
+	f.members.ignoreCase = true
+	return f
+}
+
+// AcceptValues also accept the internal values of members as items.
+func (f *EnumSetFilter) AcceptValues() *EnumSetFilter {
+	f.members.acceptValues = true
+	return f
+}
+
+// OnDeprecated set the handler called when a deprecated member is used.
+func (f *EnumSetFilter) OnDeprecated(handler EnumDeprecatedHandler) *EnumSetFilter {
+	f.onDeprecated = handler
+	return f
+}
+
+// Delimiter set the delimiter of set string.
+func (f *EnumSetFilter) Delimiter(delimiter string) *EnumSetFilter {
+	f.delimiter = delimiter
+	return f
+}
+
+// MinCount set the max item count of set.
+func (f *EnumSetFilter) MinCount(count int) *EnumSetFilter {
+	f.minCount = count
+	return f
+}
+
+// MaxCount set the max item count of set.
+func (f *EnumSetFilter) MaxCount(count int) *EnumSetFilter {
+	f.maxCount = count
+	return f
+}
+
+// AddValidator add a custom validator to filter, the validator receives the
+// mapped values.
+func (f *EnumSetFilter) AddValidator(validator EnumSetValidator) *EnumSetFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// Run make the filter running.
+func (f *EnumSetFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var fields []string
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if val != "" {
+			fields = strings.Split(val, f.delimiter)
+		}
+	case []string:
+		fields = val
+	default:
+		return nil, NewError(ErrorInvalidParam, paramName, "NotEnumSet")
+	}
+
+	enumVals := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		field = strings.Trim(field, " \t\r\n")
+		member := f.members.find(field)
+		if member == nil {
+			return nil, NewError(ErrorInvalidParam, paramName, "ItemNotInSet")
+		}
+		if member.deprecated && f.onDeprecated != nil {
+			f.onDeprecated(paramName, member.name)
+		}
+		enumVals = append(enumVals, member.value)
+	}
+
+	if len(enumVals) < f.minCount {
+		return nil, NewError(ErrorInvalidParam, paramName, "TooFew")
+	}
+	if len(enumVals) > f.maxCount {
+		return nil, NewError(ErrorInvalidParam, paramName, "TooMany")
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, enumVals); err != nil {
+			return nil, err
+		}
+	}
+
+	return enumVals, nil
+}
@@ -12,8 +12,9 @@ type IntSetFilter struct {
 	delimiter  string
 	minCount   int
 	maxCount   int
+	strict     strictMode
 	validators []IntSetValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type IntSetValidator func(paramName string, paramValue []int) *Error
@@ -28,9 +29,24 @@ func IntSet() *IntSetFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *IntSetFilter) Allow(vals ...string) *IntSetFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *IntSetFilter) AllowSpecial(special Special, vals ...string) *IntSetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *IntSetFilter) AllowSpecialFold(special Special, vals ...string) *IntSetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -61,6 +77,19 @@ func (f *IntSetFilter) MaxCount(count int) *IntSetFilter {
 	return f
 }
 
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *IntSetFilter) Strict() *IntSetFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *IntSetFilter) Lenient() *IntSetFilter {
+	f.strict = strictOff
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *IntSetFilter) AddValidator(validator IntSetValidator) *IntSetFilter {
 	f.validators = append(f.validators, validator)
@@ -155,6 +184,75 @@ func (f *IntSetFilter) ItemIn(set []int) *IntSetFilter {
 	return f
 }
 
+// Ascending valid whether values in set are strictly ascending.
+func (f *IntSetFilter) Ascending() *IntSetFilter {
+	f.AddValidator(func(paramName string, paramValue []int) *Error {
+		if !setKeysAscending(intSetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "NotAscending")
+		}
+		return nil
+	})
+	return f
+}
+
+// Unique valid whether there are no duplicate values in set.
+func (f *IntSetFilter) Unique() *IntSetFilter {
+	f.AddValidator(func(paramName string, paramValue []int) *Error {
+		if !setKeysUnique(intSetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "DuplicateItem")
+		}
+		return nil
+	})
+	return f
+}
+
+// MinGap valid whether the gap between consecutive values in ascending order
+// is not smaller than the specified value.
+func (f *IntSetFilter) MinGap(gap uint64) *IntSetFilter {
+	f.AddValidator(func(paramName string, paramValue []int) *Error {
+		min, _, ok := setKeysGaps(intSetKeys(paramValue))
+		if ok && min < gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxGap valid whether the gap between consecutive values in ascending order
+// is not larger than the specified value.
+func (f *IntSetFilter) MaxGap(gap uint64) *IntSetFilter {
+	f.AddValidator(func(paramName string, paramValue []int) *Error {
+		_, max, ok := setKeysGaps(intSetKeys(paramValue))
+		if ok && max > gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxSpan valid whether the difference between the largest and smallest
+// values in set is not larger than the specified value.
+func (f *IntSetFilter) MaxSpan(span uint64) *IntSetFilter {
+	f.AddValidator(func(paramName string, paramValue []int) *Error {
+		if setKeysSpan(intSetKeys(paramValue)) > span {
+			return NewError(ErrorInvalidParam, paramName, "SpanTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// intSetKeys map values to ordered keys.
+func intSetKeys(vals []int) []uint64 {
+	keys := make([]uint64, len(vals))
+	for i, v := range vals {
+		keys[i] = uint64(v) ^ signedKeyOffset
+	}
+	return keys
+}
+
 // Run make the filter running.
 func (f *IntSetFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -165,14 +263,15 @@ func (f *IntSetFilter) Run(paramName string, paramValue interface{}) (interface{
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		if val != "" {
 			fields := strings.Split(val, f.delimiter)
 			for _, field := range fields {
+				if word := f.strict.check(field, f.base, false); word != "" {
+					return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+				}
 				field = strings.Trim(field, " \t\r\n")
 				v, err := strconv.ParseInt(field, f.base, 0)
 				if err != nil {
@@ -189,6 +288,9 @@ func (f *IntSetFilter) Run(paramName string, paramValue interface{}) (interface{
 	case []string:
 		fields := val
 		for _, field := range fields {
+			if word := f.strict.check(field, f.base, false); word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
 			field = strings.Trim(field, " \t\r\n")
 			v, err := strconv.ParseInt(field, f.base, 0)
 			if err != nil {
@@ -202,7 +304,21 @@ func (f *IntSetFilter) Run(paramName string, paramValue interface{}) (interface{
 	case []int:
 		intVals = val
 	default:
-		goto parse_error
+		items, ok := coerceItems(val)
+		if !ok {
+			goto parse_error
+		}
+		intVals = make([]int, 0, len(items))
+		for _, item := range items {
+			v, word, ok := coerceInt64(item, int64(types.MinInt), int64(types.MaxInt))
+			if !ok {
+				goto parse_error
+			}
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
+			intVals = append(intVals, int(v))
+		}
 	}
 
 	if len(intVals) < f.minCount {
@@ -13,8 +13,9 @@ type Int32SetFilter struct {
 	delimiter  string
 	minCount   int
 	maxCount   int
+	strict     strictMode
 	validators []Int32SetValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type Int32SetValidator func(paramName string, paramValue []int32) *Error
@@ -29,9 +30,24 @@ func Int32Set() *Int32SetFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *Int32SetFilter) Allow(vals ...string) *Int32SetFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *Int32SetFilter) AllowSpecial(special Special, vals ...string) *Int32SetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *Int32SetFilter) AllowSpecialFold(special Special, vals ...string) *Int32SetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -62,6 +78,19 @@ func (f *Int32SetFilter) MaxCount(count int) *Int32SetFilter {
 	return f
 }
 
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *Int32SetFilter) Strict() *Int32SetFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *Int32SetFilter) Lenient() *Int32SetFilter {
+	f.strict = strictOff
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *Int32SetFilter) AddValidator(validator Int32SetValidator) *Int32SetFilter {
 	f.validators = append(f.validators, validator)
@@ -156,6 +185,75 @@ func (f *Int32SetFilter) ItemIn(set []int32) *Int32SetFilter {
 	return f
 }
 
+// Ascending valid whether values in set are strictly ascending.
+func (f *Int32SetFilter) Ascending() *Int32SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int32) *Error {
+		if !setKeysAscending(int32SetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "NotAscending")
+		}
+		return nil
+	})
+	return f
+}
+
+// Unique valid whether there are no duplicate values in set.
+func (f *Int32SetFilter) Unique() *Int32SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int32) *Error {
+		if !setKeysUnique(int32SetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "DuplicateItem")
+		}
+		return nil
+	})
+	return f
+}
+
+// MinGap valid whether the gap between consecutive values in ascending order
+// is not smaller than the specified value.
+func (f *Int32SetFilter) MinGap(gap uint64) *Int32SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int32) *Error {
+		min, _, ok := setKeysGaps(int32SetKeys(paramValue))
+		if ok && min < gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxGap valid whether the gap between consecutive values in ascending order
+// is not larger than the specified value.
+func (f *Int32SetFilter) MaxGap(gap uint64) *Int32SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int32) *Error {
+		_, max, ok := setKeysGaps(int32SetKeys(paramValue))
+		if ok && max > gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxSpan valid whether the difference between the largest and smallest
+// values in set is not larger than the specified value.
+func (f *Int32SetFilter) MaxSpan(span uint64) *Int32SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int32) *Error {
+		if setKeysSpan(int32SetKeys(paramValue)) > span {
+			return NewError(ErrorInvalidParam, paramName, "SpanTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// int32SetKeys map values to ordered keys.
+func int32SetKeys(vals []int32) []uint64 {
+	keys := make([]uint64, len(vals))
+	for i, v := range vals {
+		keys[i] = uint64(v) ^ signedKeyOffset
+	}
+	return keys
+}
+
 // Run make the filter running.
 func (f *Int32SetFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -166,14 +264,15 @@ func (f *Int32SetFilter) Run(paramName string, paramValue interface{}) (interfac
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		if val != "" {
 			fields := strings.Split(val, f.delimiter)
 			for _, field := range fields {
+				if word := f.strict.check(field, f.base, false); word != "" {
+					return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+				}
 				field = strings.Trim(field, " \t\r\n")
 				v, err := strconv.ParseInt(field, f.base, 64)
 				if err != nil {
@@ -190,6 +289,9 @@ func (f *Int32SetFilter) Run(paramName string, paramValue interface{}) (interfac
 	case []string:
 		fields := val
 		for _, field := range fields {
+			if word := f.strict.check(field, f.base, false); word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
 			field = strings.Trim(field, " \t\r\n")
 			v, err := strconv.ParseInt(field, f.base, 64)
 			if err != nil {
@@ -203,7 +305,21 @@ func (f *Int32SetFilter) Run(paramName string, paramValue interface{}) (interfac
 	case []int32:
 		intVals = val
 	default:
-		goto parse_error
+		items, ok := coerceItems(val)
+		if !ok {
+			goto parse_error
+		}
+		intVals = make([]int32, 0, len(items))
+		for _, item := range items {
+			v, word, ok := coerceInt64(item, math.MinInt32, math.MaxInt32)
+			if !ok {
+				goto parse_error
+			}
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
+			intVals = append(intVals, int32(v))
+		}
 	}
 
 	if len(intVals) < f.minCount {
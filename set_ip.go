@@ -12,7 +12,7 @@ type IPSetFilter struct {
 	minCount   int
 	maxCount   int
 	validators []IPSetValidator
-	allowVals  []string
+	allowVals  allowList
 	toString   bool
 }
 
@@ -33,9 +33,24 @@ func (f *IPSetFilter) ItemToString() *IPSetFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *IPSetFilter) Allow(vals ...string) *IPSetFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *IPSetFilter) AllowSpecial(special Special, vals ...string) *IPSetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *IPSetFilter) AllowSpecialFold(special Special, vals ...string) *IPSetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -101,10 +116,8 @@ func (f *IPSetFilter) Run(paramName string, paramValue interface{}) (interface{}
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		if val != "" {
 			fields := strings.Split(val, f.delimiter)
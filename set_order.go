@@ -0,0 +1,64 @@
+package filter
+
+import (
+	"sort"
+)
+
+// set items are mapped to ordered keys, the difference of two keys is the
+// difference of their items.
+const signedKeyOffset = 1 << 63
+
+// setKeysAscending check whether keys are strictly ascending.
+func setKeysAscending(keys []uint64) bool {
+	for i := 1; i < len(keys); i++ {
+		if keys[i] <= keys[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// setKeysUnique check whether there are no duplicate keys.
+func setKeysUnique(keys []uint64) bool {
+	sorted := sortedSetKeys(keys)
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// setKeysGaps return the smallest and largest gap between consecutive keys
+// in ascending order, ok is false if there are less than two keys.
+func setKeysGaps(keys []uint64) (min, max uint64, ok bool) {
+	sorted := sortedSetKeys(keys)
+	for i := 1; i < len(sorted); i++ {
+		gap := sorted[i] - sorted[i-1]
+		if !ok || gap < min {
+			min = gap
+		}
+		if !ok || gap > max {
+			max = gap
+		}
+		ok = true
+	}
+	return min, max, ok
+}
+
+// setKeysSpan return the difference between the largest and smallest key.
+func setKeysSpan(keys []uint64) uint64 {
+	if len(keys) == 0 {
+		return 0
+	}
+	sorted := sortedSetKeys(keys)
+	return sorted[len(sorted)-1] - sorted[0]
+}
+
+// sortedSetKeys return a sorted copy of keys.
+func sortedSetKeys(keys []uint64) []uint64 {
+	sorted := make([]uint64, len(keys))
+	copy(sorted, keys)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
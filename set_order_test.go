@@ -0,0 +1,18 @@
+package filter
+
+import "testing"
+
+func TestSetOrderValidators(t *testing.T) {
+	testRun(t, []runCase{
+		{IntSet().Ascending(), "-3,0,5", "[-3 0 5]", ""},
+		{IntSet().Ascending(), "1,1", "", "NotAscending"},
+		{IntSet().Unique(), "3,1,3", "", "DuplicateItem"},
+		{IntSet().MinGap(2), "5,1,3", "[5 1 3]", ""},
+		{IntSet().MinGap(3), "5,1,3", "", "GapTooSmall"},
+		{IntSet().MaxGap(2), "1,4", "", "GapTooLarge"},
+		{IntSet().MaxSpan(10), "-5,5", "[-5 5]", ""},
+		{IntSet().MaxSpan(10), "-5,6", "", "SpanTooLarge"},
+		{Int64Set().MaxSpan(1 << 63), "-9223372036854775808,9223372036854775807", "", "SpanTooLarge"},
+		{Int64Set().Ascending(), "-9223372036854775808,9223372036854775807", "[-9223372036854775808 9223372036854775807]", ""},
+	})
+}
@@ -14,7 +14,7 @@ type StringSetFilter struct {
 	minCount   int
 	maxCount   int
 	validators []StringSetValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type StringSetValidator func(paramName string, paramValue []string) *Error
@@ -29,9 +29,24 @@ func StringSet() *StringSetFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *StringSetFilter) Allow(vals ...string) *StringSetFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *StringSetFilter) AllowSpecial(special Special, vals ...string) *StringSetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *StringSetFilter) AllowSpecialFold(special Special, vals ...string) *StringSetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -265,10 +280,8 @@ func (f *StringSetFilter) Run(paramName string, paramValue interface{}) (interfa
 	var strVals []string
 	switch val := paramValue.(type) {
 	case string:
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		if val != "" {
 			strVals = strings.Split(val, f.delimiter)
@@ -1,6 +1,7 @@
 package filter
 
 import (
+	"sort"
 	"strings"
 	"time"
 
@@ -8,30 +9,47 @@ import (
 )
 
 type TimeSetFilter struct {
-	layout     string
+	layouts    []string
 	delimiter  string
 	minCount   int
 	maxCount   int
 	validators []TimeSetValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type TimeSetValidator func(paramName string, paramValue []*time.Time) *Error
 
 // TimeSet return a timestamp range filter.
+// Time bounds of validators can also be relative time expressions like
+// "now-90d", see ParseRelativeTime.
 func TimeSet() *TimeSetFilter {
 	f := new(TimeSetFilter)
 	f.delimiter = ","
-	f.layout = "2006-01-02"
+	f.layouts = []string{LayoutDate}
 	f.delimiter = ","
 	f.minCount = 0
 	f.maxCount = types.MaxInt
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *TimeSetFilter) Allow(vals ...string) *TimeSetFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *TimeSetFilter) AllowSpecial(special Special, vals ...string) *TimeSetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *TimeSetFilter) AllowSpecialFold(special Special, vals ...string) *TimeSetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -43,13 +61,22 @@ func (f *TimeSetFilter) Delimiter(delimiter string) *TimeSetFilter {
 
 // HasTime set the layout to include time.
 func (f *TimeSetFilter) HasTime() *TimeSetFilter {
-	f.layout = "2006-01-02 15:04:05"
+	f.layouts = []string{LayoutDateTime}
 	return f
 }
 
 // Layout set the time layout.
 func (f *TimeSetFilter) Layout(layout string) *TimeSetFilter {
-	f.layout = layout
+	f.layouts = []string{layout}
+	return f
+}
+
+// Layouts set the accepted time layouts, value is parsed with the layouts in
+// order and the first success wins. Preset layouts such as LayoutRFC3339 and
+// LayoutUnix can be used. An explicit zone offset in value wins over the
+// default location.
+func (f *TimeSetFilter) Layouts(layouts ...string) *TimeSetFilter {
+	f.layouts = layouts
 	return f
 }
 
@@ -74,7 +101,7 @@ func (f *TimeSetFilter) AddValidator(validator TimeSetValidator) *TimeSetFilter
 // ItemStartFrom valid whether left value in set is start from specified time.
 func (f *TimeSetFilter) ItemStartFrom(tm string) *TimeSetFilter {
 	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := parseTimeBound(f.layouts, tm, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
@@ -93,7 +120,7 @@ func (f *TimeSetFilter) ItemStartFrom(tm string) *TimeSetFilter {
 // ItemEndTo valid whether left value in set is end to specified time.
 func (f *TimeSetFilter) ItemEndTo(tm string) *TimeSetFilter {
 	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := parseTimeBound(f.layouts, tm, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
@@ -112,7 +139,7 @@ func (f *TimeSetFilter) ItemEndTo(tm string) *TimeSetFilter {
 // ItemAfter valid whether left value in set is after specified time.
 func (f *TimeSetFilter) ItemAfter(tm string) *TimeSetFilter {
 	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := parseTimeBound(f.layouts, tm, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
@@ -131,7 +158,7 @@ func (f *TimeSetFilter) ItemAfter(tm string) *TimeSetFilter {
 // ItemBefore valid whether left value in set is before specified time.
 func (f *TimeSetFilter) ItemBefore(tm string) *TimeSetFilter {
 	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := parseTimeBound(f.layouts, tm, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
@@ -150,11 +177,11 @@ func (f *TimeSetFilter) ItemBefore(tm string) *TimeSetFilter {
 // ItemBetween valid whether left value in set is in the specified range.
 func (f *TimeSetFilter) ItemBetween(startTime, endTime string) *TimeSetFilter {
 	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
-		startTime, err := time.ParseInLocation(f.layout, startTime, timeLoc)
+		startTime, err := parseTimeBound(f.layouts, startTime, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
-		endTime, err := time.ParseInLocation(f.layout, endTime, timeLoc)
+		endTime, err := parseTimeBound(f.layouts, endTime, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
@@ -173,6 +200,169 @@ func (f *TimeSetFilter) ItemBetween(startTime, endTime string) *TimeSetFilter {
 	return f
 }
 
+// ItemWorkday valid whether each value in set is a working day in the calendar.
+func (f *TimeSetFilter) ItemWorkday(cal *Calendar) *TimeSetFilter {
+	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
+		for _, v := range paramValue {
+			if !cal.IsWorkday(*v) {
+				return NewError(ErrorInvalidParam, paramName, "ItemNotWorkday")
+			}
+		}
+
+		return nil
+	})
+	return f
+}
+
+// ItemNotHoliday valid whether each value in set is not a holiday in the calendar.
+func (f *TimeSetFilter) ItemNotHoliday(cal *Calendar) *TimeSetFilter {
+	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
+		for _, v := range paramValue {
+			if cal.IsHoliday(*v) {
+				return NewError(ErrorInvalidParam, paramName, "ItemHoliday")
+			}
+		}
+
+		return nil
+	})
+	return f
+}
+
+// ItemInWorkingHours valid whether each value in set is in working hours of a working day in the calendar.
+func (f *TimeSetFilter) ItemInWorkingHours(cal *Calendar) *TimeSetFilter {
+	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
+		for _, v := range paramValue {
+			if !cal.InWorkingHours(*v) {
+				return NewError(ErrorInvalidParam, paramName, "ItemNotWorkingHours")
+			}
+		}
+
+		return nil
+	})
+	return f
+}
+
+// Ascending valid whether values in set are strictly ascending.
+func (f *TimeSetFilter) Ascending() *TimeSetFilter {
+	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
+		if !timeSetAscending(paramValue) {
+			return NewError(ErrorInvalidParam, paramName, "NotAscending")
+		}
+		return nil
+	})
+	return f
+}
+
+// Unique valid whether there are no duplicate values in set.
+func (f *TimeSetFilter) Unique() *TimeSetFilter {
+	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
+		if !timeSetUnique(paramValue) {
+			return NewError(ErrorInvalidParam, paramName, "DuplicateItem")
+		}
+		return nil
+	})
+	return f
+}
+
+// MinGap valid whether the gap between consecutive values in ascending order
+// is not smaller than the specified value.
+func (f *TimeSetFilter) MinGap(gap time.Duration) *TimeSetFilter {
+	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
+		min, _, ok := timeSetGaps(paramValue)
+		if ok && min < gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxGap valid whether the gap between consecutive values in ascending order
+// is not larger than the specified value.
+func (f *TimeSetFilter) MaxGap(gap time.Duration) *TimeSetFilter {
+	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
+		_, max, ok := timeSetGaps(paramValue)
+		if ok && max > gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxSpan valid whether the difference between the largest and smallest
+// values in set is not larger than the specified value.
+func (f *TimeSetFilter) MaxSpan(span time.Duration) *TimeSetFilter {
+	f.AddValidator(func(paramName string, paramValue []*time.Time) *Error {
+		if timeSetSpan(paramValue) > span {
+			return NewError(ErrorInvalidParam, paramName, "SpanTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// Times are compared directly instead of being mapped to set keys since
+// UnixNano overflows before 1678 and after 2262. Differences larger than
+// time.Duration saturate at its limit.
+
+// timeSetAscending check whether times are strictly ascending.
+func timeSetAscending(vals []*time.Time) bool {
+	for i := 1; i < len(vals); i++ {
+		if !vals[i].After(*vals[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// timeSetUnique check whether there are no duplicate times.
+func timeSetUnique(vals []*time.Time) bool {
+	sorted := sortedTimes(vals)
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Equal(sorted[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// timeSetGaps return the smallest and largest gap between consecutive times
+// in ascending order, ok is false if there are less than two times.
+func timeSetGaps(vals []*time.Time) (min, max time.Duration, ok bool) {
+	sorted := sortedTimes(vals)
+	for i := 1; i < len(sorted); i++ {
+		gap := sorted[i].Sub(sorted[i-1])
+		if !ok || gap < min {
+			min = gap
+		}
+		if !ok || gap > max {
+			max = gap
+		}
+		ok = true
+	}
+	return min, max, ok
+}
+
+// timeSetSpan return the difference between the latest and earliest time.
+func timeSetSpan(vals []*time.Time) time.Duration {
+	if len(vals) == 0 {
+		return 0
+	}
+	sorted := sortedTimes(vals)
+	return sorted[len(sorted)-1].Sub(sorted[0])
+}
+
+// sortedTimes return a sorted copy of times.
+func sortedTimes(vals []*time.Time) []time.Time {
+	sorted := make([]time.Time, len(vals))
+	for i, v := range vals {
+		sorted[i] = *v
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+	return sorted
+}
+
 // Run make the filter running.
 func (f *TimeSetFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -183,16 +373,14 @@ func (f *TimeSetFilter) Run(paramName string, paramValue interface{}) (interface
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		if val != "" {
 			fields := strings.Split(val, f.delimiter)
 			for _, field := range fields {
 				field = strings.Trim(field, " \t\r\n")
-				t, err := time.ParseInLocation(f.layout, field, timeLoc)
+				t, err := parseTime(f.layouts, field, timeLoc)
 				if err != nil {
 					goto parse_error
 				}
@@ -205,7 +393,7 @@ func (f *TimeSetFilter) Run(paramName string, paramValue interface{}) (interface
 		fields := val
 		for _, field := range fields {
 			field = strings.Trim(field, " \t\r\n")
-			t, err := time.ParseInLocation(f.layout, field, timeLoc)
+			t, err := parseTime(f.layouts, field, timeLoc)
 			if err != nil {
 				goto parse_error
 			}
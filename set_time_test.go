@@ -0,0 +1,26 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeSetFilter(t *testing.T) {
+	testRun(t, []runCase{
+		{TimeSet(), "2024-01-02, 2024-01-01", "[2024-01-02 00:00:00 2024-01-01 00:00:00]", ""},
+		{TimeSet(), "", "[]", ""},
+		{TimeSet().MaxCount(1), "2024-01-01,2024-01-02", "", "TooMany"},
+		{TimeSet().Ascending(), "2024-01-01,2024-01-02", "[2024-01-01 00:00:00 2024-01-02 00:00:00]", ""},
+		{TimeSet().Ascending(), "2024-01-02,2024-01-01", "", "NotAscending"},
+		{TimeSet().Ascending(), "2300-01-01,2024-01-01", "", "NotAscending"},
+		{TimeSet().Ascending(), "1600-01-01,2024-01-01", "[1600-01-01 00:00:00 2024-01-01 00:00:00]", ""},
+		{TimeSet().Ascending(), "2024-01-01,2024-01-01", "", "NotAscending"},
+		{TimeSet().Unique(), "2024-01-01,2300-01-01,2024-01-01", "", "DuplicateItem"},
+		{TimeSet().Unique(), "1600-01-01,2300-01-01", "[1600-01-01 00:00:00 2300-01-01 00:00:00]", ""},
+		{TimeSet().MinGap(48 * time.Hour), "2024-01-03,2024-01-01,2024-01-02", "", "GapTooSmall"},
+		{TimeSet().MaxGap(48 * time.Hour), "2024-01-01,2024-01-03", "[2024-01-01 00:00:00 2024-01-03 00:00:00]", ""},
+		{TimeSet().MaxGap(48 * time.Hour), "2024-01-01,2300-01-01", "", "GapTooLarge"},
+		{TimeSet().MaxSpan(24 * time.Hour), "1600-01-01,2300-01-01", "", "SpanTooLarge"},
+		{TimeSet().MaxSpan(24 * time.Hour), "2024-01-02,2024-01-01", "[2024-01-02 00:00:00 2024-01-01 00:00:00]", ""},
+	})
+}
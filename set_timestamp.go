@@ -9,11 +9,12 @@ import (
 )
 
 type TimestampSetFilter struct {
+	strict     strictMode
 	delimiter  string
 	minCount   int
 	maxCount   int
 	validators []TimestampSetValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type TimestampSetValidator func(paramName string, paramValue []uint32) *Error
@@ -27,9 +28,24 @@ func TimestampSet() *TimestampSetFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *TimestampSetFilter) Allow(vals ...string) *TimestampSetFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *TimestampSetFilter) AllowSpecial(special Special, vals ...string) *TimestampSetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *TimestampSetFilter) AllowSpecialFold(special Special, vals ...string) *TimestampSetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -51,6 +67,19 @@ func (f *TimestampSetFilter) MaxCount(count int) *TimestampSetFilter {
 	return f
 }
 
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *TimestampSetFilter) Strict() *TimestampSetFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *TimestampSetFilter) Lenient() *TimestampSetFilter {
+	f.strict = strictOff
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *TimestampSetFilter) AddValidator(validator TimestampSetValidator) *TimestampSetFilter {
 	f.validators = append(f.validators, validator)
@@ -125,6 +154,177 @@ func (f *TimestampSetFilter) ItemBetween(start, end uint32) *TimestampSetFilter
 	return f
 }
 
+// ItemStartFromRelative valid whether item value in set is start from the time of relative
+// expression, eg: "now", "today-90d". The expression is evaluated on each run.
+func (f *TimestampSetFilter) ItemStartFromRelative(expr string) *TimestampSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		for _, v := range paramValue {
+			if int64(v) < t {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooEarly")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemEndToRelative valid whether item value in set is end to the time of relative
+// expression, eg: "now", "today-90d". The expression is evaluated on each run.
+func (f *TimestampSetFilter) ItemEndToRelative(expr string) *TimestampSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		for _, v := range paramValue {
+			if int64(v) > t {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLate")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemAfterRelative valid whether item value in set is after the time of relative
+// expression, eg: "now", "today-90d". The expression is evaluated on each run.
+func (f *TimestampSetFilter) ItemAfterRelative(expr string) *TimestampSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		for _, v := range paramValue {
+			if int64(v) <= t {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooEarly")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemBeforeRelative valid whether item value in set is before the time of relative
+// expression, eg: "now", "today-90d". The expression is evaluated on each run.
+func (f *TimestampSetFilter) ItemBeforeRelative(expr string) *TimestampSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		for _, v := range paramValue {
+			if int64(v) >= t {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLate")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemBetweenRelative valid whether item value in set is between the times of two
+// relative expressions. The expressions are evaluated on each run.
+func (f *TimestampSetFilter) ItemBetweenRelative(startExpr, endExpr string) *TimestampSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		start, err := parseTimestampBound(startExpr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		end, err := parseTimestampBound(endExpr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		for _, v := range paramValue {
+			if int64(v) < start {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooEarly")
+			}
+			if int64(v) > end {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLate")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// Ascending valid whether values in set are strictly ascending.
+func (f *TimestampSetFilter) Ascending() *TimestampSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		if !setKeysAscending(timestampSetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "NotAscending")
+		}
+		return nil
+	})
+	return f
+}
+
+// Unique valid whether there are no duplicate values in set.
+func (f *TimestampSetFilter) Unique() *TimestampSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		if !setKeysUnique(timestampSetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "DuplicateItem")
+		}
+		return nil
+	})
+	return f
+}
+
+// MinGap valid whether the gap between consecutive values in ascending order
+// is not smaller than the specified value in seconds.
+func (f *TimestampSetFilter) MinGap(gap uint32) *TimestampSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		min, _, ok := setKeysGaps(timestampSetKeys(paramValue))
+		if ok && min < uint64(gap) {
+			return NewError(ErrorInvalidParam, paramName, "GapTooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxGap valid whether the gap between consecutive values in ascending order
+// is not larger than the specified value in seconds.
+func (f *TimestampSetFilter) MaxGap(gap uint32) *TimestampSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		_, max, ok := setKeysGaps(timestampSetKeys(paramValue))
+		if ok && max > uint64(gap) {
+			return NewError(ErrorInvalidParam, paramName, "GapTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxSpan valid whether the difference between the largest and smallest
+// values in set is not larger than the specified value in seconds.
+func (f *TimestampSetFilter) MaxSpan(span uint32) *TimestampSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		if setKeysSpan(timestampSetKeys(paramValue)) > uint64(span) {
+			return NewError(ErrorInvalidParam, paramName, "SpanTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// timestampSetKeys map values to ordered keys.
+func timestampSetKeys(vals []uint32) []uint64 {
+	keys := make([]uint64, len(vals))
+	for i, v := range vals {
+		keys[i] = uint64(v)
+	}
+	return keys
+}
+
 // Run make the filter running.
 func (f *TimestampSetFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -135,14 +335,15 @@ func (f *TimestampSetFilter) Run(paramName string, paramValue interface{}) (inte
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		if val != "" {
 			fields := strings.Split(val, f.delimiter)
 			for _, field := range fields {
+				if word := f.strict.check(field, 10, false); word != "" {
+					return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+				}
 				field = strings.Trim(field, " \t\r\n")
 				v, err := strconv.ParseUint(field, 10, 0)
 				if err != nil {
@@ -159,6 +360,9 @@ func (f *TimestampSetFilter) Run(paramName string, paramValue interface{}) (inte
 	case []string:
 		fields := val
 		for _, field := range fields {
+			if word := f.strict.check(field, 10, false); word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
 			field = strings.Trim(field, " \t\r\n")
 			v, err := strconv.ParseUint(field, 10, 0)
 			if err != nil {
@@ -172,7 +376,21 @@ func (f *TimestampSetFilter) Run(paramName string, paramValue interface{}) (inte
 	case []uint32:
 		tsVals = val
 	default:
-		goto parse_error
+		items, ok := coerceItems(val)
+		if !ok {
+			goto parse_error
+		}
+		tsVals = make([]uint32, 0, len(items))
+		for _, item := range items {
+			v, word, ok := coerceUint64(item, math.MaxUint32)
+			if !ok {
+				goto parse_error
+			}
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
+			tsVals = append(tsVals, uint32(v))
+		}
 	}
 
 	if len(tsVals) < f.minCount {
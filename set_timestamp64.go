@@ -0,0 +1,408 @@
+package filter
+
+import (
+	"math"
+	"strings"
+
+	"github.com/go-apibox/types"
+)
+
+type Timestamp64SetFilter struct {
+	strict     strictMode
+	unit       TimestampUnit
+	delimiter  string
+	minCount   int
+	maxCount   int
+	validators []Timestamp64SetValidator
+	allowVals  allowList
+}
+
+type Timestamp64SetValidator func(paramName string, paramValue []int64) *Error
+
+// Timestamp64Set return a int64 timestamp set filter, the unit is second by
+// default.
+func Timestamp64Set() *Timestamp64SetFilter {
+	f := new(Timestamp64SetFilter)
+	f.unit = TimestampSecond
+	f.delimiter = ","
+	f.minCount = 0
+	f.maxCount = types.MaxInt
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *Timestamp64SetFilter) Allow(vals ...string) *Timestamp64SetFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *Timestamp64SetFilter) AllowSpecial(special Special, vals ...string) *Timestamp64SetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *Timestamp64SetFilter) AllowSpecialFold(special Special, vals ...string) *Timestamp64SetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Unit set the unit of timestamp, eg: TimestampMilli for javascript timestamps.
+func (f *Timestamp64SetFilter) Unit(unit TimestampUnit) *Timestamp64SetFilter {
+	f.unit = unit
+	return f
+}
+
+// Delimiter set the delimiter of set string.
+func (f *Timestamp64SetFilter) Delimiter(delimiter string) *Timestamp64SetFilter {
+	f.delimiter = delimiter
+	return f
+}
+
+// MinCount set the max item count of set.
+func (f *Timestamp64SetFilter) MinCount(count int) *Timestamp64SetFilter {
+	f.minCount = count
+	return f
+}
+
+// MaxCount set the max item count of set.
+func (f *Timestamp64SetFilter) MaxCount(count int) *Timestamp64SetFilter {
+	f.maxCount = count
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *Timestamp64SetFilter) Strict() *Timestamp64SetFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *Timestamp64SetFilter) Lenient() *Timestamp64SetFilter {
+	f.strict = strictOff
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *Timestamp64SetFilter) AddValidator(validator Timestamp64SetValidator) *Timestamp64SetFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// ItemStartFrom valid whether item value in set is start from specified time.
+func (f *Timestamp64SetFilter) ItemStartFrom(val int64) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		for _, v := range paramValue {
+			if v < val {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooEarly")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemEndTo valid whether item value in set is end to specified time.
+func (f *Timestamp64SetFilter) ItemEndTo(val int64) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		for _, v := range paramValue {
+			if v > val {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLate")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemAfter valid whether item value in set is after specified time.
+func (f *Timestamp64SetFilter) ItemAfter(val int64) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		for _, v := range paramValue {
+			if v <= val {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooEarly")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemBefore valid whether item value in set is before specified time.
+func (f *Timestamp64SetFilter) ItemBefore(val int64) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		for _, v := range paramValue {
+			if v >= val {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLate")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemBetween valid whether item value in set is in the specified range.
+func (f *Timestamp64SetFilter) ItemBetween(start, end int64) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		for _, v := range paramValue {
+			if v < start {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooEarly")
+			}
+			if v > end {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLate")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemStartFromRelative valid whether item value in set is start from the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64SetFilter) ItemStartFromRelative(expr string) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		for _, v := range paramValue {
+			if v < t {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooEarly")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemEndToRelative valid whether item value in set is end to the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64SetFilter) ItemEndToRelative(expr string) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		for _, v := range paramValue {
+			if v > t {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLate")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemAfterRelative valid whether item value in set is after the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64SetFilter) ItemAfterRelative(expr string) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		for _, v := range paramValue {
+			if v <= t {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooEarly")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemBeforeRelative valid whether item value in set is before the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64SetFilter) ItemBeforeRelative(expr string) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		for _, v := range paramValue {
+			if v >= t {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLate")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ItemBetweenRelative valid whether item value in set is between the times of
+// two relative expressions. The expressions are evaluated on each run.
+func (f *Timestamp64SetFilter) ItemBetweenRelative(startExpr, endExpr string) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		start, err := parseTimestamp64Bound(startExpr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		end, err := parseTimestamp64Bound(endExpr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		for _, v := range paramValue {
+			if v < start {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooEarly")
+			}
+			if v > end {
+				return NewError(ErrorInvalidParam, paramName, "ItemTooLate")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// Ascending valid whether values in set are strictly ascending.
+func (f *Timestamp64SetFilter) Ascending() *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		if !setKeysAscending(timestamp64SetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "NotAscending")
+		}
+		return nil
+	})
+	return f
+}
+
+// Unique valid whether there are no duplicate values in set.
+func (f *Timestamp64SetFilter) Unique() *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		if !setKeysUnique(timestamp64SetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "DuplicateItem")
+		}
+		return nil
+	})
+	return f
+}
+
+// MinGap valid whether the gap between consecutive values in ascending order
+// is not smaller than the specified value in unit of filter.
+func (f *Timestamp64SetFilter) MinGap(gap uint64) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		min, _, ok := setKeysGaps(timestamp64SetKeys(paramValue))
+		if ok && min < gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxGap valid whether the gap between consecutive values in ascending order
+// is not larger than the specified value in unit of filter.
+func (f *Timestamp64SetFilter) MaxGap(gap uint64) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		_, max, ok := setKeysGaps(timestamp64SetKeys(paramValue))
+		if ok && max > gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxSpan valid whether the difference between the largest and smallest
+// values in set is not larger than the specified value in unit of filter.
+func (f *Timestamp64SetFilter) MaxSpan(span uint64) *Timestamp64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []int64) *Error {
+		if setKeysSpan(timestamp64SetKeys(paramValue)) > span {
+			return NewError(ErrorInvalidParam, paramName, "SpanTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// timestamp64SetKeys map values to ordered keys.
+func timestamp64SetKeys(vals []int64) []uint64 {
+	keys := make([]uint64, len(vals))
+	for i, v := range vals {
+		keys[i] = uint64(v) ^ signedKeyOffset
+	}
+	return keys
+}
+
+// Run make the filter running.
+func (f *Timestamp64SetFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var fields []string
+	var tsVals []int64
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if val != "" {
+			fields = strings.Split(val, f.delimiter)
+		}
+		tsVals = []int64{}
+	case []string:
+		fields = val
+	case []int64:
+		tsVals = val
+	default:
+		items, ok := coerceItems(val)
+		if !ok {
+			goto parse_error
+		}
+		tsVals = make([]int64, 0, len(items))
+		for _, item := range items {
+			v, word, ok := coerceInt64(item, math.MinInt64, math.MaxInt64)
+			if !ok {
+				goto parse_error
+			}
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
+			tsVals = append(tsVals, v)
+		}
+	}
+
+	for _, field := range fields {
+		if word := f.strict.check(field, 10, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+		}
+		field = strings.Trim(field, " \t\r\n")
+		v, err := parseTimestamp64(field)
+		if err == errTimestampOverflow {
+			return nil, NewError(ErrorInvalidParam, paramName, "ItemTimestampOverflow")
+		}
+		if err != nil {
+			goto parse_error
+		}
+		tsVals = append(tsVals, v)
+	}
+
+	if len(tsVals) < f.minCount {
+		return nil, NewError(ErrorInvalidParam, paramName, "TooFew")
+	}
+	if len(tsVals) > f.maxCount {
+		return nil, NewError(ErrorInvalidParam, paramName, "TooMany")
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, tsVals); err != nil {
+			return nil, err
+		}
+	}
+
+	return tsVals, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotTimestamp64Set")
+}
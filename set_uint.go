@@ -12,8 +12,9 @@ type UintSetFilter struct {
 	delimiter  string
 	minCount   int
 	maxCount   int
+	strict     strictMode
 	validators []UintSetValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type UintSetValidator func(paramName string, paramValue []uint) *Error
@@ -28,9 +29,24 @@ func UintSet() *UintSetFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *UintSetFilter) Allow(vals ...string) *UintSetFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *UintSetFilter) AllowSpecial(special Special, vals ...string) *UintSetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *UintSetFilter) AllowSpecialFold(special Special, vals ...string) *UintSetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -61,6 +77,19 @@ func (f *UintSetFilter) MaxCount(count int) *UintSetFilter {
 	return f
 }
 
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *UintSetFilter) Strict() *UintSetFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *UintSetFilter) Lenient() *UintSetFilter {
+	f.strict = strictOff
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *UintSetFilter) AddValidator(validator UintSetValidator) *UintSetFilter {
 	f.validators = append(f.validators, validator)
@@ -155,6 +184,75 @@ func (f *UintSetFilter) ItemIn(set []uint) *UintSetFilter {
 	return f
 }
 
+// Ascending valid whether values in set are strictly ascending.
+func (f *UintSetFilter) Ascending() *UintSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint) *Error {
+		if !setKeysAscending(uintSetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "NotAscending")
+		}
+		return nil
+	})
+	return f
+}
+
+// Unique valid whether there are no duplicate values in set.
+func (f *UintSetFilter) Unique() *UintSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint) *Error {
+		if !setKeysUnique(uintSetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "DuplicateItem")
+		}
+		return nil
+	})
+	return f
+}
+
+// MinGap valid whether the gap between consecutive values in ascending order
+// is not smaller than the specified value.
+func (f *UintSetFilter) MinGap(gap uint64) *UintSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint) *Error {
+		min, _, ok := setKeysGaps(uintSetKeys(paramValue))
+		if ok && min < gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxGap valid whether the gap between consecutive values in ascending order
+// is not larger than the specified value.
+func (f *UintSetFilter) MaxGap(gap uint64) *UintSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint) *Error {
+		_, max, ok := setKeysGaps(uintSetKeys(paramValue))
+		if ok && max > gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxSpan valid whether the difference between the largest and smallest
+// values in set is not larger than the specified value.
+func (f *UintSetFilter) MaxSpan(span uint64) *UintSetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint) *Error {
+		if setKeysSpan(uintSetKeys(paramValue)) > span {
+			return NewError(ErrorInvalidParam, paramName, "SpanTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// uintSetKeys map values to ordered keys.
+func uintSetKeys(vals []uint) []uint64 {
+	keys := make([]uint64, len(vals))
+	for i, v := range vals {
+		keys[i] = uint64(v)
+	}
+	return keys
+}
+
 // Run make the filter running.
 func (f *UintSetFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -165,14 +263,15 @@ func (f *UintSetFilter) Run(paramName string, paramValue interface{}) (interface
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		if val != "" {
 			fields := strings.Split(val, f.delimiter)
 			for _, field := range fields {
+				if word := f.strict.check(field, f.base, false); word != "" {
+					return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+				}
 				field = strings.Trim(field, " \t\r\n")
 				v, err := strconv.ParseUint(field, f.base, 0)
 				if err != nil {
@@ -189,6 +288,9 @@ func (f *UintSetFilter) Run(paramName string, paramValue interface{}) (interface
 	case []string:
 		fields := val
 		for _, field := range fields {
+			if word := f.strict.check(field, f.base, false); word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
 			field = strings.Trim(field, " \t\r\n")
 			v, err := strconv.ParseUint(field, f.base, 0)
 			if err != nil {
@@ -202,7 +304,21 @@ func (f *UintSetFilter) Run(paramName string, paramValue interface{}) (interface
 	case []uint:
 		intVals = val
 	default:
-		goto parse_error
+		items, ok := coerceItems(val)
+		if !ok {
+			goto parse_error
+		}
+		intVals = make([]uint, 0, len(items))
+		for _, item := range items {
+			v, word, ok := coerceUint64(item, uint64(types.MaxUint))
+			if !ok {
+				goto parse_error
+			}
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
+			intVals = append(intVals, uint(v))
+		}
 	}
 
 	if len(intVals) < f.minCount {
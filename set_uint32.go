@@ -13,8 +13,9 @@ type Uint32SetFilter struct {
 	delimiter  string
 	minCount   int
 	maxCount   int
+	strict     strictMode
 	validators []Uint32SetValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type Uint32SetValidator func(paramName string, paramValue []uint32) *Error
@@ -29,9 +30,24 @@ func Uint32Set() *Uint32SetFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *Uint32SetFilter) Allow(vals ...string) *Uint32SetFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *Uint32SetFilter) AllowSpecial(special Special, vals ...string) *Uint32SetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *Uint32SetFilter) AllowSpecialFold(special Special, vals ...string) *Uint32SetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -62,6 +78,19 @@ func (f *Uint32SetFilter) MaxCount(count int) *Uint32SetFilter {
 	return f
 }
 
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *Uint32SetFilter) Strict() *Uint32SetFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *Uint32SetFilter) Lenient() *Uint32SetFilter {
+	f.strict = strictOff
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *Uint32SetFilter) AddValidator(validator Uint32SetValidator) *Uint32SetFilter {
 	f.validators = append(f.validators, validator)
@@ -156,6 +185,75 @@ func (f *Uint32SetFilter) ItemIn(set []uint32) *Uint32SetFilter {
 	return f
 }
 
+// Ascending valid whether values in set are strictly ascending.
+func (f *Uint32SetFilter) Ascending() *Uint32SetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		if !setKeysAscending(uint32SetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "NotAscending")
+		}
+		return nil
+	})
+	return f
+}
+
+// Unique valid whether there are no duplicate values in set.
+func (f *Uint32SetFilter) Unique() *Uint32SetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		if !setKeysUnique(uint32SetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "DuplicateItem")
+		}
+		return nil
+	})
+	return f
+}
+
+// MinGap valid whether the gap between consecutive values in ascending order
+// is not smaller than the specified value.
+func (f *Uint32SetFilter) MinGap(gap uint64) *Uint32SetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		min, _, ok := setKeysGaps(uint32SetKeys(paramValue))
+		if ok && min < gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxGap valid whether the gap between consecutive values in ascending order
+// is not larger than the specified value.
+func (f *Uint32SetFilter) MaxGap(gap uint64) *Uint32SetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		_, max, ok := setKeysGaps(uint32SetKeys(paramValue))
+		if ok && max > gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxSpan valid whether the difference between the largest and smallest
+// values in set is not larger than the specified value.
+func (f *Uint32SetFilter) MaxSpan(span uint64) *Uint32SetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint32) *Error {
+		if setKeysSpan(uint32SetKeys(paramValue)) > span {
+			return NewError(ErrorInvalidParam, paramName, "SpanTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// uint32SetKeys map values to ordered keys.
+func uint32SetKeys(vals []uint32) []uint64 {
+	keys := make([]uint64, len(vals))
+	for i, v := range vals {
+		keys[i] = uint64(v)
+	}
+	return keys
+}
+
 // Run make the filter running.
 func (f *Uint32SetFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -166,14 +264,15 @@ func (f *Uint32SetFilter) Run(paramName string, paramValue interface{}) (interfa
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		if val != "" {
 			fields := strings.Split(val, f.delimiter)
 			for _, field := range fields {
+				if word := f.strict.check(field, f.base, false); word != "" {
+					return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+				}
 				field = strings.Trim(field, " \t\r\n")
 				v, err := strconv.ParseUint(field, f.base, 32)
 				if err != nil {
@@ -190,6 +289,9 @@ func (f *Uint32SetFilter) Run(paramName string, paramValue interface{}) (interfa
 	case []string:
 		fields := val
 		for _, field := range fields {
+			if word := f.strict.check(field, f.base, false); word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
 			field = strings.Trim(field, " \t\r\n")
 			v, err := strconv.ParseUint(field, f.base, 32)
 			if err != nil {
@@ -205,7 +307,21 @@ func (f *Uint32SetFilter) Run(paramName string, paramValue interface{}) (interfa
 			intVals = append(intVals, uint32(v))
 		}
 	default:
-		goto parse_error
+		items, ok := coerceItems(val)
+		if !ok {
+			goto parse_error
+		}
+		intVals = make([]uint32, 0, len(items))
+		for _, item := range items {
+			v, word, ok := coerceUint64(item, math.MaxUint32)
+			if !ok {
+				goto parse_error
+			}
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
+			intVals = append(intVals, uint32(v))
+		}
 	}
 
 	if len(intVals) < f.minCount {
@@ -1,6 +1,7 @@
 package filter
 
 import (
+	"math"
 	"strconv"
 	"strings"
 
@@ -12,8 +13,9 @@ type Uint64SetFilter struct {
 	delimiter  string
 	minCount   int
 	maxCount   int
+	strict     strictMode
 	validators []Uint64SetValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type Uint64SetValidator func(paramName string, paramValue []uint64) *Error
@@ -28,9 +30,24 @@ func Uint64Set() *Uint64SetFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *Uint64SetFilter) Allow(vals ...string) *Uint64SetFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *Uint64SetFilter) AllowSpecial(special Special, vals ...string) *Uint64SetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *Uint64SetFilter) AllowSpecialFold(special Special, vals ...string) *Uint64SetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -61,6 +78,19 @@ func (f *Uint64SetFilter) MaxCount(count int) *Uint64SetFilter {
 	return f
 }
 
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *Uint64SetFilter) Strict() *Uint64SetFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *Uint64SetFilter) Lenient() *Uint64SetFilter {
+	f.strict = strictOff
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *Uint64SetFilter) AddValidator(validator Uint64SetValidator) *Uint64SetFilter {
 	f.validators = append(f.validators, validator)
@@ -155,6 +185,75 @@ func (f *Uint64SetFilter) ItemIn(set []uint64) *Uint64SetFilter {
 	return f
 }
 
+// Ascending valid whether values in set are strictly ascending.
+func (f *Uint64SetFilter) Ascending() *Uint64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint64) *Error {
+		if !setKeysAscending(uint64SetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "NotAscending")
+		}
+		return nil
+	})
+	return f
+}
+
+// Unique valid whether there are no duplicate values in set.
+func (f *Uint64SetFilter) Unique() *Uint64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint64) *Error {
+		if !setKeysUnique(uint64SetKeys(paramValue)) {
+			return NewError(ErrorInvalidParam, paramName, "DuplicateItem")
+		}
+		return nil
+	})
+	return f
+}
+
+// MinGap valid whether the gap between consecutive values in ascending order
+// is not smaller than the specified value.
+func (f *Uint64SetFilter) MinGap(gap uint64) *Uint64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint64) *Error {
+		min, _, ok := setKeysGaps(uint64SetKeys(paramValue))
+		if ok && min < gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooSmall")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxGap valid whether the gap between consecutive values in ascending order
+// is not larger than the specified value.
+func (f *Uint64SetFilter) MaxGap(gap uint64) *Uint64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint64) *Error {
+		_, max, ok := setKeysGaps(uint64SetKeys(paramValue))
+		if ok && max > gap {
+			return NewError(ErrorInvalidParam, paramName, "GapTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// MaxSpan valid whether the difference between the largest and smallest
+// values in set is not larger than the specified value.
+func (f *Uint64SetFilter) MaxSpan(span uint64) *Uint64SetFilter {
+	f.AddValidator(func(paramName string, paramValue []uint64) *Error {
+		if setKeysSpan(uint64SetKeys(paramValue)) > span {
+			return NewError(ErrorInvalidParam, paramName, "SpanTooLarge")
+		}
+		return nil
+	})
+	return f
+}
+
+// uint64SetKeys map values to ordered keys.
+func uint64SetKeys(vals []uint64) []uint64 {
+	keys := make([]uint64, len(vals))
+	for i, v := range vals {
+		keys[i] = v
+	}
+	return keys
+}
+
 // Run make the filter running.
 func (f *Uint64SetFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -165,14 +264,15 @@ func (f *Uint64SetFilter) Run(paramName string, paramValue interface{}) (interfa
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
 		if val != "" {
 			fields := strings.Split(val, f.delimiter)
 			for _, field := range fields {
+				if word := f.strict.check(field, f.base, false); word != "" {
+					return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+				}
 				field = strings.Trim(field, " \t\r\n")
 				v, err := strconv.ParseUint(field, f.base, 64)
 				if err != nil {
@@ -186,6 +286,9 @@ func (f *Uint64SetFilter) Run(paramName string, paramValue interface{}) (interfa
 	case []string:
 		fields := val
 		for _, field := range fields {
+			if word := f.strict.check(field, f.base, false); word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
 			field = strings.Trim(field, " \t\r\n")
 			v, err := strconv.ParseUint(field, f.base, 64)
 			if err != nil {
@@ -196,7 +299,21 @@ func (f *Uint64SetFilter) Run(paramName string, paramValue interface{}) (interfa
 	case []uint64:
 		intVals = val
 	default:
-		goto parse_error
+		items, ok := coerceItems(val)
+		if !ok {
+			goto parse_error
+		}
+		intVals = make([]uint64, 0, len(items))
+		for _, item := range items {
+			v, word, ok := coerceUint64(item, math.MaxUint64)
+			if !ok {
+				goto parse_error
+			}
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, "Item"+word)
+			}
+			intVals = append(intVals, v)
+		}
 	}
 
 	if len(intVals) < f.minCount {
@@ -0,0 +1,206 @@
+package filter
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-apibox/types"
+)
+
+type WeekdaySetFilter struct {
+	delimiter  string
+	minCount   int
+	maxCount   int
+	validators []WeekdaySetValidator
+	allowVals  allowList
+}
+
+type WeekdaySetValidator func(paramName string, paramValue []time.Weekday) *Error
+
+var errInvalidWeekday = errors.New("invalid weekday")
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// Weekdays return a weekday set filter, it accepts names like "mon,wed,fri",
+// ranges like "mon-fri" and numbers where both 0 and 7 are sunday, and
+// output []time.Weekday.
+func Weekdays() *WeekdaySetFilter {
+	f := new(WeekdaySetFilter)
+	f.delimiter = ","
+	f.minCount = 0
+	f.maxCount = types.MaxInt
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *WeekdaySetFilter) Allow(vals ...string) *WeekdaySetFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *WeekdaySetFilter) AllowSpecial(special Special, vals ...string) *WeekdaySetFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *WeekdaySetFilter) AllowSpecialFold(special Special, vals ...string) *WeekdaySetFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Delimiter set the delimiter in set string.
+func (f *WeekdaySetFilter) Delimiter(delimiter string) *WeekdaySetFilter {
+	f.delimiter = delimiter
+	return f
+}
+
+// MinCount set the minimum count of set.
+func (f *WeekdaySetFilter) MinCount(count int) *WeekdaySetFilter {
+	f.minCount = count
+	return f
+}
+
+// MaxCount set the maximum count of set.
+func (f *WeekdaySetFilter) MaxCount(count int) *WeekdaySetFilter {
+	f.maxCount = count
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *WeekdaySetFilter) AddValidator(validator WeekdaySetValidator) *WeekdaySetFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// ItemIn valid whether each weekday in set is in the specified weekdays.
+func (f *WeekdaySetFilter) ItemIn(days ...time.Weekday) *WeekdaySetFilter {
+	f.AddValidator(func(paramName string, paramValue []time.Weekday) *Error {
+		for _, v := range paramValue {
+			found := false
+			for _, day := range days {
+				if v == day {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return NewError(ErrorInvalidParam, paramName, "ItemNotInSet")
+			}
+		}
+		return nil
+	})
+	return f
+}
+
+// ParseWeekday parse a weekday name ("mon", "monday") or number (0-7, both 0
+// and 7 are sunday).
+func ParseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if day, ok := weekdayNames[s]; ok {
+		return day, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 && n <= 7 {
+		return time.Weekday(n % 7), nil
+	}
+	return 0, errInvalidWeekday
+}
+
+// parseWeekdays parse a weekday list, ranges like "mon-fri" and "fri-mon"
+// are expanded in week order.
+func parseWeekdays(fields []string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, field := range fields {
+		parts := strings.Split(field, "-")
+		if len(parts) > 2 {
+			return nil, errInvalidWeekday
+		}
+		start, err := ParseWeekday(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if len(parts) == 2 {
+			if end, err = ParseWeekday(parts[1]); err != nil {
+				return nil, err
+			}
+		}
+		for day := start; ; day = (day + 1) % 7 {
+			days = append(days, day)
+			if day == end {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// Run make the filter running.
+func (f *WeekdaySetFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var days []time.Weekday
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if val != "" {
+			var err error
+			days, err = parseWeekdays(strings.Split(val, f.delimiter))
+			if err != nil {
+				goto parse_error
+			}
+		}
+	case []string:
+		var err error
+		days, err = parseWeekdays(val)
+		if err != nil {
+			goto parse_error
+		}
+	case []time.Weekday:
+		for _, day := range val {
+			if day < time.Sunday || day > time.Saturday {
+				goto parse_error
+			}
+		}
+		days = val
+	default:
+		goto parse_error
+	}
+
+	if len(days) < f.minCount {
+		return nil, NewError(ErrorInvalidParam, paramName, "TooFew")
+	}
+	if len(days) > f.maxCount {
+		return nil, NewError(ErrorInvalidParam, paramName, "TooMany")
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, days); err != nil {
+			return nil, err
+		}
+	}
+
+	return days, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotWeekdaySet")
+}
@@ -0,0 +1,59 @@
+package filter
+
+import "strings"
+
+// Special is a typed sentinel returned by filters for allowed special values
+// instead of a parsed value, eg: All for "all". Special values skip the
+// validators of filter.
+type Special string
+
+// predefined special values
+const (
+	All       Special = "all"
+	None      Special = "none"
+	Auto      Special = "auto"
+	Unlimited Special = "unlimited"
+)
+
+// IsSpecial check whether the result of a filter is a special value.
+func IsSpecial(v interface{}) bool {
+	_, ok := v.(Special)
+	return ok
+}
+
+// String return the name of special value.
+func (s Special) String() string {
+	return string(s)
+}
+
+// allowValue is an allowed special value of a filter.
+type allowValue struct {
+	val        string
+	special    Special
+	ignoreCase bool
+}
+
+type allowList []allowValue
+
+// add add allowed values mapped to special, each value is mapped to itself
+// as a Special if special is empty.
+func (l allowList) add(special Special, ignoreCase bool, vals ...string) allowList {
+	for _, val := range vals {
+		s := special
+		if s == "" {
+			s = Special(val)
+		}
+		l = append(l, allowValue{val, s, ignoreCase})
+	}
+	return l
+}
+
+// match return the special value which s is mapped to.
+func (l allowList) match(s string) (Special, bool) {
+	for _, v := range l {
+		if v.val == s || (v.ignoreCase && strings.EqualFold(v.val, s)) {
+			return v.special, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,49 @@
+package filter
+
+import "testing"
+
+func TestSpecialValues(t *testing.T) {
+	const custom Special = "custom"
+	tests := []struct {
+		f    runner
+		in   string
+		want Special
+		word string
+	}{
+		{Int().Min(10).Allow("all"), "all", Special("all"), ""},
+		{Int().AllowSpecial(All, "all", "*"), "*", All, ""},
+		{Int().AllowSpecial(All, "all"), "ALL", "", "NotInt"},
+		{Int().AllowSpecialFold(All, "all"), " ALL ", All, ""},
+		{Int64().Strict().AllowSpecial(Unlimited, "-1"), "-1", Unlimited, ""},
+		{Float64().AllowSpecial(Auto, "auto"), "auto", Auto, ""},
+		{Decimal().AllowSpecial(None, "none"), "none", None, ""},
+		{Timestamp64Range().AllowSpecial(custom, "x"), "x", custom, ""},
+		{TimeOfDay().AllowSpecial(All, "any"), "any", All, ""},
+		{Weekdays().AllowSpecialFold(All, "every"), "Every", All, ""},
+	}
+	for i, tt := range tests {
+		v, err := tt.f.Run("p", tt.in)
+		if tt.word != "" {
+			if got := errWord(err); got != tt.word {
+				t.Errorf("#%d Run(%q) error = %v, want %q", i, tt.in, err, tt.word)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("#%d Run(%q) error = %v", i, tt.in, err)
+			continue
+		}
+		if !IsSpecial(v) || v != tt.want {
+			t.Errorf("#%d Run(%q) = %#v, want %#v", i, tt.in, v, tt.want)
+		}
+	}
+}
+
+func TestIsSpecial(t *testing.T) {
+	if IsSpecial("all") {
+		t.Error(`IsSpecial("all") = true, want false`)
+	}
+	if !IsSpecial(All) || All.String() != "all" {
+		t.Errorf("IsSpecial(All) = false or All.String() = %q", All.String())
+	}
+}
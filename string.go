@@ -16,7 +16,7 @@ type StringFilter struct {
 	strcase    int
 	trim       bool
 	validators []StringValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type StringValidator func(paramName string, paramValue string) *Error
@@ -28,9 +28,24 @@ func String() *StringFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *StringFilter) Allow(vals ...string) *StringFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *StringFilter) AllowSpecial(special Special, vals ...string) *StringFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *StringFilter) AllowSpecialFold(special Special, vals ...string) *StringFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -222,10 +237,8 @@ func (f *StringFilter) Run(paramName string, paramValue interface{}) (interface{
 	if !ok {
 		return nil, NewError(ErrorInvalidParam, paramName, "NotString")
 	}
-	for _, allowVal := range f.allowVals {
-		if allowVal == strVal {
-			return strVal, nil
-		}
+	if special, ok := f.allowVals.match(strVal); ok {
+		return special, nil
 	}
 
 	switch f.strcase {
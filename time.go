@@ -1,40 +1,164 @@
 package filter
 
 import (
+	"math"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type TimeFilter struct {
-	layout     string
-	validators []TimeValidator
-	allowVals  []string
+	layouts      []string
+	autoDetect   bool
+	natural      bool
+	ambiguity    TimeAmbiguity
+	minYear      int
+	maxYear      int
+	output       int
+	outputLayout string
+	outputLoc    *time.Location
+	clamp        bool
+	clampStart   string
+	clampEnd     string
+	clampHook    ClampHook
+	validators   []TimeValidator
+	allowVals    allowList
 }
 
 type TimeValidator func(paramName string, paramValue *time.Time) *Error
 
 // Time return a time filter.
+// Time bounds of validators can also be relative time expressions like
+// "now-90d", see ParseRelativeTime.
 func Time() *TimeFilter {
 	f := new(TimeFilter)
-	f.layout = "2006-01-02"
+	f.layouts = []string{LayoutDate}
+	f.minYear = defaultDetectMinYear
+	f.maxYear = defaultDetectMaxYear
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *TimeFilter) Allow(vals ...string) *TimeFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *TimeFilter) AllowSpecial(special Special, vals ...string) *TimeFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *TimeFilter) AllowSpecialFold(special Special, vals ...string) *TimeFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
 // HasTime set the layout to include time.
 func (f *TimeFilter) HasTime() *TimeFilter {
-	f.layout = "2006-01-02 15:04:05"
+	f.layouts = []string{LayoutDateTime}
 	return f
 }
 
 // Layout set the time layout.
 func (f *TimeFilter) Layout(layout string) *TimeFilter {
-	f.layout = layout
+	f.layouts = []string{layout}
+	return f
+}
+
+// Layouts set the accepted time layouts, value is parsed with the layouts in
+// order and the first success wins. Preset layouts such as LayoutRFC3339 and
+// LayoutUnix can be used. An explicit zone offset in value wins over the
+// default location.
+func (f *TimeFilter) Layouts(layouts ...string) *TimeFilter {
+	f.layouts = layouts
+	return f
+}
+
+// AutoDetect detect the format of value from the layouts in order, all layouts
+// are tried and multiple matches are resolved by Ambiguity. Numbers are also
+// accepted. DefaultDetectLayouts is used if no layout is given.
+func (f *TimeFilter) AutoDetect(layouts ...string) *TimeFilter {
+	if len(layouts) == 0 {
+		layouts = DefaultDetectLayouts
+	}
+	f.layouts = layouts
+	f.autoDetect = true
+	return f
+}
+
+// Natural accept natural language times in Chinese and English like
+// "2024年3月5日 下午3点", "昨天", "3天前", "下周一", "tomorrow 3pm" and
+// "2 days ago" when value does not match the layouts. They are evaluated at
+// the time of clock, see ParseNaturalTime and SetClock.
+func (f *TimeFilter) Natural() *TimeFilter {
+	f.natural = true
+	return f
+}
+
+// Ambiguity set the rule to resolve multiple matches in auto detect mode,
+// default is AmbiguityMagnitude.
+func (f *TimeFilter) Ambiguity(ambiguity TimeAmbiguity) *TimeFilter {
+	f.ambiguity = ambiguity
+	return f
+}
+
+// DetectYears set the accepted year window of AmbiguityReject, default is
+// 1980 to 2099.
+func (f *TimeFilter) DetectYears(minYear, maxYear int) *TimeFilter {
+	f.minYear = minYear
+	f.maxYear = maxYear
+	return f
+}
+
+// ToTime output the value as *time.Time, this is the default.
+func (f *TimeFilter) ToTime() *TimeFilter {
+	f.output = timeOutputTime
+	return f
+}
+
+// ToUnix output the value as int64 unix seconds.
+func (f *TimeFilter) ToUnix() *TimeFilter {
+	f.output = timeOutputUnix
+	return f
+}
+
+// ToUnixMilli output the value as int64 unix milliseconds.
+func (f *TimeFilter) ToUnixMilli() *TimeFilter {
+	f.output = timeOutputUnixMilli
+	return f
+}
+
+// ToLayout output the value as string formatted with layout in loc, nil loc
+// means the default location.
+func (f *TimeFilter) ToLayout(layout string, loc *time.Location) *TimeFilter {
+	if loc == nil {
+		loc = timeLoc
+	}
+	f.output = timeOutputLayout
+	f.outputLayout = layout
+	f.outputLoc = loc
+	return f
+}
+
+// Clamp move param value earlier than startTime or later than endTime onto
+// the nearest limit instead of failing, validators run on the adjusted value.
+func (f *TimeFilter) Clamp(startTime, endTime string) *TimeFilter {
+	f.clamp = true
+	f.clampStart = startTime
+	f.clampEnd = endTime
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped, values are in the output form of the filter.
+func (f *TimeFilter) OnClamp(hook ClampHook) *TimeFilter {
+	f.clampHook = hook
 	return f
 }
 
@@ -47,7 +171,7 @@ func (f *TimeFilter) AddValidator(validator TimeValidator) *TimeFilter {
 // StartFrom valid whether start from specified time.
 func (f *TimeFilter) StartFrom(tm string) *TimeFilter {
 	f.AddValidator(func(paramName string, paramValue *time.Time) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := parseTimeBound(f.layouts, tm, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
@@ -64,7 +188,7 @@ func (f *TimeFilter) StartFrom(tm string) *TimeFilter {
 // EndTo valid whether end to specified time.
 func (f *TimeFilter) EndTo(tm string) *TimeFilter {
 	f.AddValidator(func(paramName string, paramValue *time.Time) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := parseTimeBound(f.layouts, tm, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
@@ -81,7 +205,7 @@ func (f *TimeFilter) EndTo(tm string) *TimeFilter {
 // After valid whether after specified time.
 func (f *TimeFilter) After(tm string) *TimeFilter {
 	f.AddValidator(func(paramName string, paramValue *time.Time) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := parseTimeBound(f.layouts, tm, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
@@ -98,7 +222,7 @@ func (f *TimeFilter) After(tm string) *TimeFilter {
 // Before valid whether before specified time.
 func (f *TimeFilter) Before(tm string) *TimeFilter {
 	f.AddValidator(func(paramName string, paramValue *time.Time) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := parseTimeBound(f.layouts, tm, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
@@ -115,7 +239,7 @@ func (f *TimeFilter) Before(tm string) *TimeFilter {
 // Equal valid whether equal to specified time.
 func (f *TimeFilter) Equal(tm string) *TimeFilter {
 	f.AddValidator(func(paramName string, paramValue *time.Time) *Error {
-		t, err := time.ParseInLocation(f.layout, tm, timeLoc)
+		t, err := parseTimeBound(f.layouts, tm, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
@@ -135,11 +259,11 @@ func (f *TimeFilter) Equal(tm string) *TimeFilter {
 // Between valid whether between two times.
 func (f *TimeFilter) Between(startTime, endTime string) *TimeFilter {
 	f.AddValidator(func(paramName string, paramValue *time.Time) *Error {
-		startTime, err := time.ParseInLocation(f.layout, startTime, timeLoc)
+		startTime, err := parseTimeBound(f.layouts, startTime, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
-		endTime, err := time.ParseInLocation(f.layout, endTime, timeLoc)
+		endTime, err := parseTimeBound(f.layouts, endTime, timeLoc)
 		if err != nil {
 			return NewError(ErrorInternalError, paramName, "InvalidValidator")
 		}
@@ -156,6 +280,68 @@ func (f *TimeFilter) Between(startTime, endTime string) *TimeFilter {
 	return f
 }
 
+// Workday valid whether is a working day in the calendar.
+func (f *TimeFilter) Workday(cal *Calendar) *TimeFilter {
+	f.AddValidator(func(paramName string, paramValue *time.Time) *Error {
+		if !cal.IsWorkday(*paramValue) {
+			return NewError(ErrorInvalidParam, paramName, "NotWorkday")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// NotHoliday valid whether is not a holiday in the calendar.
+func (f *TimeFilter) NotHoliday(cal *Calendar) *TimeFilter {
+	f.AddValidator(func(paramName string, paramValue *time.Time) *Error {
+		if cal.IsHoliday(*paramValue) {
+			return NewError(ErrorInvalidParam, paramName, "Holiday")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// InWorkingHours valid whether is in working hours of a working day in the calendar.
+func (f *TimeFilter) InWorkingHours(cal *Calendar) *TimeFilter {
+	f.AddValidator(func(paramName string, paramValue *time.Time) *Error {
+		if !cal.InWorkingHours(*paramValue) {
+			return NewError(ErrorInvalidParam, paramName, "NotWorkingHours")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// clampValue move t onto the nearest limit of clamp range.
+func (f *TimeFilter) clampValue(paramName string, t *time.Time) (*time.Time, error) {
+	start, err := parseTimeBound(f.layouts, f.clampStart, timeLoc)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseTimeBound(f.layouts, f.clampEnd, timeLoc)
+	if err != nil {
+		return nil, err
+	}
+	if start.After(end) {
+		return nil, errInvalidClamp
+	}
+
+	adjusted := t
+	if t.Before(start) {
+		adjusted = &start
+	} else if t.After(end) {
+		adjusted = &end
+	}
+	if adjusted != t && f.clampHook != nil {
+		f.clampHook(paramName, f.outputTime(t), f.outputTime(adjusted))
+	}
+	return adjusted, nil
+}
+
 // Run make the filter running.
 func (f *TimeFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -166,12 +352,46 @@ func (f *TimeFilter) Run(paramName string, paramValue interface{}) (interface{},
 	switch val := paramValue.(type) {
 	case string:
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		var t time.Time
+		var err error
+		if f.autoDetect {
+			t, err = detectTime(f.layouts, val, timeLoc, f.ambiguity, f.minYear, f.maxYear)
+		} else {
+			t, err = parseTime(f.layouts, val, timeLoc)
+		}
+		if err == errInvalidTime && f.natural {
+			t, err = ParseNaturalTime(val, now(), timeLoc)
+		}
+		if err == errAmbiguousTime {
+			return nil, NewError(ErrorInvalidParam, paramName, "AmbiguousTime")
+		}
+		if err != nil {
+			goto parse_error
+		}
+		timeVal = &t
+	case int, int64, float64:
+		if !f.autoDetect {
+			goto parse_error
+		}
+		var num string
+		switch v := val.(type) {
+		case int:
+			num = strconv.Itoa(v)
+		case int64:
+			num = strconv.FormatInt(v, 10)
+		case float64:
+			if v != math.Trunc(v) || math.Abs(v) >= 1<<63 {
+				goto parse_error
 			}
+			num = strconv.FormatInt(int64(v), 10)
+		}
+		t, err := detectTime(f.layouts, num, timeLoc, f.ambiguity, f.minYear, f.maxYear)
+		if err == errAmbiguousTime {
+			return nil, NewError(ErrorInvalidParam, paramName, "AmbiguousTime")
 		}
-		t, err := time.ParseInLocation(f.layout, val, timeLoc)
 		if err != nil {
 			goto parse_error
 		}
@@ -184,13 +404,20 @@ func (f *TimeFilter) Run(paramName string, paramValue interface{}) (interface{},
 		goto parse_error
 	}
 
+	if f.clamp {
+		var err error
+		if timeVal, err = f.clampValue(paramName, timeVal); err != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+	}
+
 	for _, validator := range f.validators {
 		if err := validator(paramName, timeVal); err != nil {
 			return nil, err
 		}
 	}
 
-	return timeVal, nil
+	return f.outputTime(timeVal), nil
 
 parse_error:
 	return nil, NewError(ErrorInvalidParam, paramName, "NotTime")
@@ -0,0 +1,121 @@
+package filter
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// TimeAmbiguity decide which result wins when a value matches more than one
+// layout in auto detect mode.
+type TimeAmbiguity int
+
+const (
+	// AmbiguityMagnitude pick unix seconds or milliseconds by the magnitude of
+	// number, values with 12 or more digits are milliseconds. Other conflicts
+	// are resolved by detection order.
+	AmbiguityMagnitude TimeAmbiguity = iota
+	// AmbiguityFirst pick the first matched layout in detection order.
+	AmbiguityFirst
+	// AmbiguityReject reject the value if more than one matched layout gives
+	// a different time inside the accepted year window, matches outside the
+	// window are ignored, eg: "1709600000" is a time in 2024 as unix seconds
+	// but Jan 1970 as milliseconds. If no match is inside the window, the
+	// value is ambiguous when matches differ and invalid otherwise.
+	AmbiguityReject
+)
+
+// time output representations
+const (
+	timeOutputTime = iota
+	timeOutputUnix
+	timeOutputUnixMilli
+	timeOutputLayout
+)
+
+// DefaultDetectLayouts is the default detection order of auto detect mode.
+var DefaultDetectLayouts = []string{
+	LayoutUnix,
+	LayoutUnixMilli,
+	LayoutRFC3339Nano,
+	LayoutDateTime,
+	LayoutDate,
+}
+
+// unix seconds below this magnitude, milliseconds from it
+const unixMilliThreshold = 100000000000
+
+// default accepted year window of AmbiguityReject, unix seconds and
+// milliseconds inside it never overlap
+const (
+	defaultDetectMinYear = 1980
+	defaultDetectMaxYear = 2099
+)
+
+var errAmbiguousTime = errors.New("ambiguous time")
+
+// detectTime parse value with all the layouts and resolve multiple matches
+// with the ambiguity rule, minYear and maxYear is the year window accepted
+// by AmbiguityReject.
+func detectTime(layouts []string, value string, loc *time.Location, ambiguity TimeAmbiguity, minYear, maxYear int) (time.Time, error) {
+	var matched []string
+	var times []time.Time
+	for _, layout := range layouts {
+		if t, err := parseTime([]string{layout}, value, loc); err == nil {
+			matched = append(matched, layout)
+			times = append(times, t)
+		}
+	}
+	if len(times) == 0 {
+		return time.Time{}, errInvalidTime
+	}
+
+	switch ambiguity {
+	case AmbiguityReject:
+		var result time.Time
+		found := false
+		for _, t := range times {
+			if t.Year() < minYear || t.Year() > maxYear {
+				continue
+			}
+			if found && !t.Equal(result) {
+				return time.Time{}, errAmbiguousTime
+			}
+			result, found = t, true
+		}
+		if found {
+			return result, nil
+		}
+		for _, t := range times[1:] {
+			if !t.Equal(times[0]) {
+				return time.Time{}, errAmbiguousTime
+			}
+		}
+		return time.Time{}, errInvalidTime
+	case AmbiguityMagnitude:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			break
+		}
+		isMilli := v >= unixMilliThreshold || v <= -unixMilliThreshold
+		for i, layout := range matched {
+			if (layout == LayoutUnix && !isMilli) || (layout == LayoutUnixMilli && isMilli) {
+				return times[i], nil
+			}
+		}
+	}
+	return times[0], nil
+}
+
+// outputTime convert t to the representation chosen by filter.
+func (f *TimeFilter) outputTime(t *time.Time) interface{} {
+	switch f.output {
+	case timeOutputUnix:
+		return t.Unix()
+	case timeOutputUnixMilli:
+		return t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond)
+	case timeOutputLayout:
+		return t.In(f.outputLoc).Format(f.outputLayout)
+	}
+	return t
+}
@@ -0,0 +1,29 @@
+package filter
+
+import "testing"
+
+func TestTimeAutoDetect(t *testing.T) {
+	reject := func() *TimeFilter {
+		return Time().AutoDetect().Ambiguity(AmbiguityReject).ToLayout(LayoutDateTime, nil)
+	}
+	testRun(t, []runCase{
+		{Time().AutoDetect().ToUnix(), "1709600000", "1709600000", ""},
+		{Time().AutoDetect().ToUnixMilli(), "1709600000123", "1709600000123", ""},
+		{Time().AutoDetect().ToUnix(), 1709600000, "1709600000", ""},
+		{Time().AutoDetect().ToUnix(), float64(1709600000), "1709600000", ""},
+		{Time().AutoDetect().ToUnix(), 1.5, "", "NotTime"},
+		{Time().AutoDetect().ToLayout(LayoutDateTime, nil), "2024-03-05", "2024-03-05 00:00:00", ""},
+		{Time().AutoDetect().Ambiguity(AmbiguityFirst).ToUnix(), "1709600000123", "1709600000123", ""},
+		{Time().AutoDetect(), "yesterday", "", "NotTime"},
+		{Time(), 1709600000, "", "NotTime"},
+
+		{reject(), "1709600000", "2024-03-05 08:53:20", ""},
+		{reject(), "1709600000123", "2024-03-05 08:53:20", ""},
+		{reject(), 1709600000, "2024-03-05 08:53:20", ""},
+		{reject(), "2024-03-05", "2024-03-05 00:00:00", ""},
+		{reject().DetectYears(1970, 2099), "1709600000", "", "AmbiguousTime"},
+		{reject(), "100", "", "AmbiguousTime"},
+		{reject(), "1900-01-01", "", "NotTime"},
+		{reject().DetectYears(1900, 2099), "1900-01-01", "1900-01-01 00:00:00", ""},
+	})
+}
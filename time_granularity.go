@@ -0,0 +1,67 @@
+package filter
+
+import (
+	"time"
+)
+
+// TimeGranularity is the unit of time values in a range.
+type TimeGranularity int
+
+// time granularities
+const (
+	GranularitySecond TimeGranularity = iota + 1
+	GranularityMinute
+	GranularityDay
+	GranularityMonth
+)
+
+// layoutGranularity return the finest unit that values of the layouts can
+// express, eg: GranularityDay for LayoutDate.
+func layoutGranularity(layouts []string) TimeGranularity {
+	ref := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	g := GranularityMonth
+	for _, layout := range layouts {
+		if layout == LayoutUnix || layout == LayoutUnixMilli {
+			return GranularitySecond
+		}
+		t, err := time.Parse(layout, ref.Format(layout))
+		switch {
+		case err != nil || t.Second() == ref.Second():
+			return GranularitySecond
+		case t.Hour() == ref.Hour() || t.Minute() == ref.Minute():
+			g = GranularityMinute
+		case t.Day() == ref.Day() && g > GranularityDay:
+			g = GranularityDay
+		}
+	}
+	return g
+}
+
+// truncate return the start of the unit which t belongs to, in the location
+// of t.
+func (g TimeGranularity) truncate(t time.Time) time.Time {
+	switch g {
+	case GranularitySecond:
+		return t.Truncate(time.Second)
+	case GranularityMinute:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location())
+	case GranularityDay:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	case GranularityMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	return t
+}
+
+// step return t moved by n units, one second if granularity is not set.
+func (g TimeGranularity) step(t time.Time, n int) time.Time {
+	switch g {
+	case GranularityMinute:
+		return t.Add(time.Duration(n) * time.Minute)
+	case GranularityDay:
+		return t.AddDate(0, 0, n)
+	case GranularityMonth:
+		return t.AddDate(0, n, 0)
+	}
+	return t.Add(time.Duration(n) * time.Second)
+}
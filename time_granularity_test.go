@@ -0,0 +1,38 @@
+package filter
+
+import "testing"
+
+func TestLayoutGranularity(t *testing.T) {
+	tests := []struct {
+		layouts []string
+		want    TimeGranularity
+	}{
+		{[]string{LayoutDate}, GranularityDay},
+		{[]string{"2006-01"}, GranularityMonth},
+		{[]string{"2006-01-02 15:04"}, GranularityMinute},
+		{[]string{LayoutDateTime}, GranularitySecond},
+		{[]string{LayoutDate, LayoutDateTime}, GranularitySecond},
+		{[]string{"2006-01", LayoutDate}, GranularityDay},
+		{[]string{LayoutUnix}, GranularitySecond},
+		{[]string{LayoutRFC3339}, GranularitySecond},
+	}
+	for _, tt := range tests {
+		if got := layoutGranularity(tt.layouts); got != tt.want {
+			t.Errorf("layoutGranularity(%v) = %d, want %d", tt.layouts, got, tt.want)
+		}
+	}
+}
+
+func TestTimeRangeGranularity(t *testing.T) {
+	testRun(t, []runCase{
+		{TimeRange().HalfOpen(), "2024-01-01~2024-01-31", "[2024-01-01 00:00:00,2024-02-01 00:00:00)", ""},
+		{TimeRange().HalfOpen(), "(2024-01-01,2024-01-31)", "[2024-01-02 00:00:00,2024-01-31 00:00:00)", ""},
+		{TimeRange().Layout("2006-01").HalfOpen(), "2024-01~2024-03", "[2024-01-01 00:00:00,2024-04-01 00:00:00)", ""},
+		{TimeRange().HasTime().HalfOpen(), "2024-01-01 00:00:00~2024-01-31 12:00:00", "[2024-01-01 00:00:00,2024-01-31 12:00:01)", ""},
+		{TimeRange().Granularity(GranularityMonth).HalfOpen(), "2024-01-15~2024-01-20", "[2024-01-01 00:00:00,2024-02-01 00:00:00)", ""},
+		{TimeRange().Granularity(GranularityDay), "2024-01-01~2024-01-31", "[2024-01-01 00:00:00,2024-01-31 23:59:59]", ""},
+		{TimeRange().LeftStartFrom("2024-01-10"), "(2024-01-09,2024-01-31]", "(2024-01-09 00:00:00,2024-01-31 00:00:00]", ""},
+		{TimeRange().LeftStartFrom("2024-01-10"), "(2024-01-08,2024-01-31]", "", "LeftTooEarly"},
+		{TimeRange().RightEndTo("2024-01-31"), "[2024-01-01,2024-02-01)", "[2024-01-01 00:00:00,2024-02-01 00:00:00)", ""},
+	})
+}
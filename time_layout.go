@@ -0,0 +1,42 @@
+package filter
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// preset time layouts
+const (
+	LayoutRFC3339     = time.RFC3339
+	LayoutRFC3339Nano = time.RFC3339Nano
+	LayoutDate        = "2006-01-02"
+	LayoutDateTime    = "2006-01-02 15:04:05"
+	LayoutUnix        = "unix"      // unix timestamp in seconds
+	LayoutUnixMilli   = "unixmilli" // unix timestamp in milliseconds
+)
+
+var errInvalidTime = errors.New("invalid time")
+
+// parseTime parse the value with layouts in order and return the first
+// success. Values without zone information are parsed in loc, an explicit
+// offset in value wins over loc.
+func parseTime(layouts []string, value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range layouts {
+		switch layout {
+		case LayoutUnix:
+			if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return time.Unix(v, 0).In(loc), nil
+			}
+		case LayoutUnixMilli:
+			if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+				return time.Unix(v/1000, v%1000*int64(time.Millisecond)).In(loc), nil
+			}
+		default:
+			if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, errInvalidTime
+}
@@ -0,0 +1,53 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	layouts := []string{LayoutRFC3339, LayoutDateTime, LayoutDate, LayoutUnix}
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"2024-03-05", "2024-03-05 00:00:00 +0800", true},
+		{"2024-03-05 10:20:30", "2024-03-05 10:20:30 +0800", true},
+		{"2024-03-05T10:20:30Z", "2024-03-05 10:20:30 +0000", true},
+		{"2024-03-05T10:20:30+02:00", "2024-03-05 10:20:30 +0200", true},
+		{"1709600000", "2024-03-05 08:53:20 +0800", true},
+		{"2024/03/05", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, err := parseTime(layouts, tt.in, timeLoc)
+		if (err == nil) != tt.ok {
+			t.Errorf("parseTime(%q) error = %v", tt.in, err)
+			continue
+		}
+		if err == nil && got.Format("2006-01-02 15:04:05 -0700") != tt.want {
+			t.Errorf("parseTime(%q) = %v, want %s", tt.in, got, tt.want)
+		}
+	}
+
+	got, err := parseTime([]string{LayoutUnixMilli}, "1709600000123", timeLoc)
+	if err != nil || got.UnixNano() != 1709600000123*int64(time.Millisecond) {
+		t.Errorf("parseTime(unixmilli) = %v, %v", got, err)
+	}
+}
+
+func TestTimeLayouts(t *testing.T) {
+	testRun(t, []runCase{
+		{Time(), "2024-03-05", "2024-03-05 00:00:00", ""},
+		{Time(), "2024-03-05 10:00:00", "", "NotTime"},
+		{Time().HasTime(), "2024-03-05 10:00:00", "2024-03-05 10:00:00", ""},
+		{Time().Layouts(LayoutDateTime, LayoutDate), "2024-03-05", "2024-03-05 00:00:00", ""},
+		{Time().Layouts(LayoutDateTime, LayoutDate), "2024-03-05 08:30:00", "2024-03-05 08:30:00", ""},
+		{Time().Layouts(LayoutUnix).ToUnix(), "1709600000", "1709600000", ""},
+		{Time().Layouts(LayoutRFC3339).ToLayout(LayoutDateTime, nil), "2024-03-05T00:00:00Z", "2024-03-05 08:00:00", ""},
+		{TimeSet().Layouts(LayoutDateTime, LayoutDate), "2024-03-05,2024-03-06 12:00:00", "[2024-03-05 00:00:00 2024-03-06 12:00:00]", ""},
+		{TimeSet(), "2024-03-05,x", "", "NotTimeSet"},
+		{TimeRange().Layouts(LayoutDateTime, LayoutDate), "[2024-03-05,2024-03-06 12:00:00)", "[2024-03-05 00:00:00,2024-03-06 12:00:00)", ""},
+	})
+}
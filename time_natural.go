@@ -0,0 +1,376 @@
+package filter
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxNaturalOffsetYears is the largest offset of natural times like
+// "3 years ago", larger offsets are rejected instead of overflowing.
+const maxNaturalOffsetYears = 10000
+
+var (
+	naturalCnNum  = `[0-9]+|[零〇一二两三四五六七八九十]+`
+	naturalEnNum  = `[0-9]+|an?|one|two|three|four|five|six|seven|eight|nine|ten|eleven|twelve`
+	naturalEnUnit = `second|sec|minute|min|hour|hr|day|week|month|year`
+
+	naturalCnOffsetRegexp = regexp.MustCompile(`^(` + naturalCnNum + `)\s*(秒钟?|分钟|个?小时|个?钟头|天|日|周|个?星期|个?礼拜|个月|月|年)\s*(前|后|以前|以后|之前|之后)$`)
+	naturalEnAgoRegexp    = regexp.MustCompile(`^(` + naturalEnNum + `)\s+(` + naturalEnUnit + `)s?\s+ago$`)
+	naturalEnLaterRegexp  = regexp.MustCompile(`^(?:in\s+(` + naturalEnNum + `)\s+(` + naturalEnUnit + `)s?|(` + naturalEnNum + `)\s+(` + naturalEnUnit + `)s?\s+(?:later|from\s+now))$`)
+
+	naturalCnTimeRegexp = regexp.MustCompile(`^(.*?)\s*(上午|早上|早晨|凌晨|中午|下午|傍晚|晚上|夜里|夜间)?\s*(` + naturalCnNum + `)\s*[点點时時]\s*(半|一刻|三刻|(?:` + naturalCnNum + `)\s*分?)?\s*(?:(` + naturalCnNum + `)\s*秒)?$`)
+	naturalClockRegexp  = regexp.MustCompile(`^(.*?)\s*(?:at\s+)?([0-9]{1,2}):([0-9]{2})(?::([0-9]{2}))?\s*(am|pm|a\.m\.|p\.m\.)?$`)
+	naturalEnTimeRegexp = regexp.MustCompile(`^(.*?)\s*(?:at\s+)?([0-9]{1,2})\s*(am|pm|a\.m\.|p\.m\.)$`)
+
+	naturalCnWeekdayRegexp = regexp.MustCompile(`^(上个?|下个?|本|这个?)?(?:周|星期|礼拜)([一二三四五六日天])$`)
+	naturalEnWeekdayRegexp = regexp.MustCompile(`^(?:(next|last|this)\s+)?(monday|mon|tuesday|tue|tues|wednesday|wed|thursday|thu|thur|thurs|friday|fri|saturday|sat|sunday|sun)$`)
+	naturalCnDateRegexp    = regexp.MustCompile(`^(?:([0-9]{4})\s*年\s*)?([0-9]{1,2})\s*月\s*([0-9]{1,2})\s*[日号號]$`)
+	naturalEnDateRegexp    = regexp.MustCompile(`^(january|jan|february|feb|march|mar|april|apr|may|june|jun|july|jul|august|aug|september|sept|sep|october|oct|november|nov|december|dec)\.?\s+([0-9]{1,2})(?:st|nd|rd|th)?(?:,?\s+([0-9]{4}))?$`)
+	naturalEnDate2Regexp   = regexp.MustCompile(`^([0-9]{1,2})(?:st|nd|rd|th)?\s+(january|jan|february|feb|march|mar|april|apr|may|june|jun|july|jul|august|aug|september|sept|sep|october|oct|november|nov|december|dec)\.?(?:,?\s+([0-9]{4}))?$`)
+	naturalYMDRegexp       = regexp.MustCompile(`^([0-9]{4})[-/.]([0-9]{1,2})[-/.]([0-9]{1,2})$`)
+	naturalDMYRegexp       = regexp.MustCompile(`^([0-9]{1,2})[/.]([0-9]{1,2})[/.]([0-9]{4})$`)
+)
+
+var naturalCnDays = map[string]int{
+	"今天": 0, "今日": 0, "明天": 1, "明日": 1, "后天": 2, "大后天": 3,
+	"昨天": -1, "昨日": -1, "前天": -2, "大前天": -3,
+}
+
+var naturalEnDays = map[string]int{
+	"today": 0, "tomorrow": 1, "yesterday": -1,
+	"the day after tomorrow": 2, "day after tomorrow": 2,
+	"the day before yesterday": -2, "day before yesterday": -2,
+}
+
+var naturalEnNumbers = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+var naturalCnWeekdays = map[string]time.Weekday{
+	"一": time.Monday, "二": time.Tuesday, "三": time.Wednesday, "四": time.Thursday,
+	"五": time.Friday, "六": time.Saturday, "日": time.Sunday, "天": time.Sunday,
+}
+
+var naturalEnMonths = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+// ParseNaturalTime parse a natural language time in Chinese or English
+// evaluated at now in loc, such as "2024年3月5日 下午3点", "昨天", "3天前",
+// "下周一", "tomorrow 3pm", "2 days ago", "next monday" and "March 5, 2024".
+// Days start from midnight unless a time is given, offsets like "3天前" keep
+// the time of now. Weeks start from monday. Input which can not be resolved
+// without guessing, like a bare "周一" or "03/05/2024", returns an ambiguous
+// time error.
+func ParseNaturalTime(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	s = strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	now = now.In(loc)
+	if s == "" {
+		return time.Time{}, errInvalidTime
+	}
+	if s == "now" || s == "现在" || s == "此刻" {
+		return now, nil
+	}
+	if t, ok, err := parseNaturalOffset(s, now); ok {
+		return t, err
+	}
+
+	// split the time of day from the end
+	datePart := s
+	hasClock := false
+	var hour, minute, second int
+	if m := naturalCnTimeRegexp.FindStringSubmatch(s); m != nil {
+		h, err1 := parseNaturalNumber(m[3])
+		mi, err2 := parseCnMinute(m[4])
+		sec, err3 := parseNaturalNumber(m[5])
+		if err1 != nil || err2 != nil || (m[5] != "" && err3 != nil) {
+			return time.Time{}, errInvalidTime
+		}
+		hour, minute, second = cnPeriodHour(m[2], h), mi, sec
+		datePart, hasClock = m[1], true
+	} else if m := naturalClockRegexp.FindStringSubmatch(s); m != nil {
+		hour, _ = strconv.Atoi(m[2])
+		minute, _ = strconv.Atoi(m[3])
+		second, _ = strconv.Atoi(m[4] + "0")
+		second /= 10
+		hour = enPeriodHour(m[5], hour)
+		datePart, hasClock = m[1], true
+	} else if m := naturalEnTimeRegexp.FindStringSubmatch(s); m != nil {
+		hour, _ = strconv.Atoi(m[2])
+		if hour < 1 || hour > 12 {
+			return time.Time{}, errInvalidTime
+		}
+		hour = enPeriodHour(m[3], hour)
+		datePart, hasClock = m[1], true
+	}
+	if hasClock && (hour < 0 || hour > 24 || minute > 59 || second > 59 || (hour == 24 && minute+second > 0)) {
+		return time.Time{}, errInvalidTime
+	}
+
+	datePart = strings.TrimSpace(datePart)
+	var day time.Time
+	if datePart == "" {
+		if !hasClock {
+			return time.Time{}, errInvalidTime
+		}
+		day = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	} else {
+		var err error
+		if day, err = parseNaturalDate(datePart, now); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, loc), nil
+}
+
+// parseNaturalOffset parse offsets like "3天前", "2 days ago" and "in 2 hours".
+func parseNaturalOffset(s string, now time.Time) (time.Time, bool, error) {
+	var num, unit string
+	sign := 1
+	if m := naturalCnOffsetRegexp.FindStringSubmatch(s); m != nil {
+		num, unit = m[1], strings.TrimPrefix(m[2], "个")
+		if strings.HasSuffix(m[3], "前") {
+			sign = -1
+		}
+		if m[2] == "月" {
+			// "3月前" reads like march, only "3个月前" is accepted
+			return time.Time{}, true, errAmbiguousTime
+		}
+	} else if m := naturalEnAgoRegexp.FindStringSubmatch(s); m != nil {
+		num, unit, sign = m[1], m[2], -1
+	} else if m := naturalEnLaterRegexp.FindStringSubmatch(s); m != nil {
+		num, unit = m[1]+m[3], m[2]+m[4]
+	} else {
+		return time.Time{}, false, nil
+	}
+
+	n, err := parseNaturalNumber(num)
+	if err != nil {
+		return time.Time{}, true, errInvalidTime
+	}
+	var d time.Duration
+	var years, months, days int
+	switch unit {
+	case "秒", "秒钟", "second", "sec":
+		d = time.Second
+	case "分钟", "minute", "min":
+		d = time.Minute
+	case "小时", "钟头", "hour", "hr":
+		d = time.Hour
+	case "天", "日", "day":
+		days = 1
+	case "周", "星期", "礼拜", "week":
+		days = 7
+	case "月", "month":
+		months = 1
+	case "年", "year":
+		years = 1
+	default:
+		return time.Time{}, true, errInvalidTime
+	}
+
+	if d > 0 {
+		if int64(n) > math.MaxInt64/int64(d) {
+			return time.Time{}, true, errInvalidTime
+		}
+		return now.Add(time.Duration(sign*n) * d), true, nil
+	}
+	if n > maxNaturalOffsetYears*366/(366*years+31*months+days) {
+		return time.Time{}, true, errInvalidTime
+	}
+	n *= sign
+	return now.AddDate(n*years, n*months, n*days), true, nil
+}
+
+// parseNaturalDate parse the date part and return the midnight of it.
+func parseNaturalDate(s string, now time.Time) (time.Time, error) {
+	loc := now.Location()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if n, ok := naturalCnDays[s]; ok {
+		return today.AddDate(0, 0, n), nil
+	}
+	if n, ok := naturalEnDays[s]; ok {
+		return today.AddDate(0, 0, n), nil
+	}
+
+	if m := naturalCnWeekdayRegexp.FindStringSubmatch(s); m != nil {
+		weeks := 0
+		switch {
+		case m[1] == "":
+			return time.Time{}, errAmbiguousTime
+		case strings.HasPrefix(m[1], "上"):
+			weeks = -1
+		case strings.HasPrefix(m[1], "下"):
+			weeks = 1
+		}
+		return weekdayOfWeek(today, weeks, naturalCnWeekdays[m[2]]), nil
+	}
+	if m := naturalEnWeekdayRegexp.FindStringSubmatch(s); m != nil {
+		weeks := 0
+		switch m[1] {
+		case "":
+			return time.Time{}, errAmbiguousTime
+		case "last":
+			weeks = -1
+		case "next":
+			weeks = 1
+		}
+		day, _ := ParseWeekday(m[2][:3])
+		return weekdayOfWeek(today, weeks, day), nil
+	}
+
+	var year, day int
+	var month time.Month
+	if m := naturalCnDateRegexp.FindStringSubmatch(s); m != nil {
+		year = parseNaturalYear(m[1], now)
+		mo, _ := strconv.Atoi(m[2])
+		month = time.Month(mo)
+		day, _ = strconv.Atoi(m[3])
+	} else if m := naturalEnDateRegexp.FindStringSubmatch(s); m != nil {
+		month = naturalEnMonths[m[1][:3]]
+		day, _ = strconv.Atoi(m[2])
+		year = parseNaturalYear(m[3], now)
+	} else if m := naturalEnDate2Regexp.FindStringSubmatch(s); m != nil {
+		day, _ = strconv.Atoi(m[1])
+		month = naturalEnMonths[m[2][:3]]
+		year = parseNaturalYear(m[3], now)
+	} else if m := naturalYMDRegexp.FindStringSubmatch(s); m != nil {
+		year, _ = strconv.Atoi(m[1])
+		mo, _ := strconv.Atoi(m[2])
+		month = time.Month(mo)
+		day, _ = strconv.Atoi(m[3])
+	} else if m := naturalDMYRegexp.FindStringSubmatch(s); m != nil {
+		a, _ := strconv.Atoi(m[1])
+		b, _ := strconv.Atoi(m[2])
+		year, _ = strconv.Atoi(m[3])
+		switch {
+		case a > 12:
+			day, month = a, time.Month(b)
+		case b > 12 || a == b:
+			month, day = time.Month(a), b
+		default:
+			// month/day or day/month
+			return time.Time{}, errAmbiguousTime
+		}
+	} else {
+		return time.Time{}, errInvalidTime
+	}
+
+	t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if month < time.January || month > time.December || t.Day() != day {
+		return time.Time{}, errInvalidTime
+	}
+	return t, nil
+}
+
+// weekdayOfWeek return the weekday in the week of today moved by weeks.
+func weekdayOfWeek(today time.Time, weeks int, day time.Weekday) time.Time {
+	monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	return monday.AddDate(0, 0, 7*weeks+(int(day)+6)%7)
+}
+
+// parseNaturalYear return the year, or the year of now if it's empty.
+func parseNaturalYear(s string, now time.Time) int {
+	if s == "" {
+		return now.Year()
+	}
+	year, _ := strconv.Atoi(s)
+	return year
+}
+
+// parseNaturalNumber parse arabic, chinese (below 100) or english numbers.
+func parseNaturalNumber(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, nil
+	}
+	if n, ok := naturalEnNumbers[s]; ok {
+		return n, nil
+	}
+
+	digits := map[rune]int{'零': 0, '〇': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9}
+	runes := []rune(s)
+	n, tens := 0, -1
+	for i, r := range runes {
+		if r == '十' {
+			if tens >= 0 {
+				return 0, errInvalidTime
+			}
+			tens = 1
+			if i > 0 {
+				tens = n
+			}
+			n = 0
+			continue
+		}
+		d, ok := digits[r]
+		if !ok || (i > 0 && tens < 0) {
+			return 0, errInvalidTime
+		}
+		n = d
+	}
+	if tens >= 0 {
+		return tens*10 + n, nil
+	}
+	return n, nil
+}
+
+// parseCnMinute parse the minute part after "点".
+func parseCnMinute(s string) (int, error) {
+	switch s {
+	case "半":
+		return 30, nil
+	case "一刻":
+		return 15, nil
+	case "三刻":
+		return 45, nil
+	}
+	return parseNaturalNumber(strings.TrimSpace(strings.TrimSuffix(s, "分")))
+}
+
+// cnPeriodHour convert the hour with chinese period of day to 24-hour clock.
+func cnPeriodHour(period string, hour int) int {
+	switch period {
+	case "上午", "早上", "早晨", "凌晨":
+		if hour == 12 {
+			return 0
+		}
+	case "中午":
+		if hour < 11 {
+			return hour + 12
+		}
+	case "下午", "傍晚":
+		if hour < 12 {
+			return hour + 12
+		}
+	case "晚上", "夜里", "夜间":
+		// "晚上12点" is the midnight at the end of day
+		if hour <= 12 {
+			return hour + 12
+		}
+	}
+	return hour
+}
+
+// enPeriodHour convert the hour with am or pm to 24-hour clock.
+func enPeriodHour(period string, hour int) int {
+	switch strings.Replace(period, ".", "", -1) {
+	case "am":
+		if hour == 12 {
+			return 0
+		}
+	case "pm":
+		if hour < 12 {
+			return hour + 12
+		}
+	}
+	return hour
+}
@@ -0,0 +1,51 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseNaturalTime(t *testing.T) {
+	// a thursday
+	now := time.Date(2024, 5, 16, 10, 30, 0, 0, timeLoc)
+	tests := []struct {
+		in   string
+		want string
+		err  error
+	}{
+		{"now", "2024-05-16 10:30:00", nil},
+		{"昨天", "2024-05-15 00:00:00", nil},
+		{"明天 下午3点半", "2024-05-17 15:30:00", nil},
+		{"3天前", "2024-05-13 10:30:00", nil},
+		{"两个小时后", "2024-05-16 12:30:00", nil},
+		{"3个月前", "2024-02-16 10:30:00", nil},
+		{"3月前", "", errAmbiguousTime},
+		{"下周一", "2024-05-20 00:00:00", nil},
+		{"周一", "", errAmbiguousTime},
+		{"2024年3月5日 下午3点", "2024-03-05 15:00:00", nil},
+		{"tomorrow 3pm", "2024-05-17 15:00:00", nil},
+		{"2 days ago", "2024-05-14 10:30:00", nil},
+		{"in 2 hours", "2024-05-16 12:30:00", nil},
+		{"a week from now", "2024-05-23 10:30:00", nil},
+		{"next monday", "2024-05-20 00:00:00", nil},
+		{"March 5, 2024", "2024-03-05 00:00:00", nil},
+		{"03/05/2024", "", errAmbiguousTime},
+		{"", "", errInvalidTime},
+		{"999999999999 hours ago", "", errInvalidTime},
+		{"99999999999999 years ago", "", errInvalidTime},
+		{"99999999999999 days later", "", errInvalidTime},
+		{"1000 hours ago", "2024-04-04 18:30:00", nil},
+		{"10001 years ago", "", errInvalidTime},
+		{"10000 years later", "12024-05-16 10:30:00", nil},
+	}
+	for _, tt := range tests {
+		got, err := ParseNaturalTime(tt.in, now, timeLoc)
+		if err != tt.err {
+			t.Errorf("ParseNaturalTime(%q) error = %v, want %v", tt.in, err, tt.err)
+			continue
+		}
+		if err == nil && got.Format(LayoutDateTime) != tt.want {
+			t.Errorf("ParseNaturalTime(%q) = %s, want %s", tt.in, got.Format(LayoutDateTime), tt.want)
+		}
+	}
+}
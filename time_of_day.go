@@ -0,0 +1,180 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClockTime is a time of day as the duration since midnight, "24:00" is
+// allowed as the end of day.
+type ClockTime time.Duration
+
+// EndOfDay is the clock time "24:00".
+const EndOfDay = ClockTime(24 * time.Hour)
+
+var errInvalidClockTime = errors.New("invalid time of day")
+
+// ParseClockTime parse a time of day in "15:04" or "15:04:05" format.
+func ParseClockTime(s string) (ClockTime, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, errInvalidClockTime
+	}
+	var nums [3]int
+	for i, part := range parts {
+		if len(part) != 2 {
+			return 0, errInvalidClockTime
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, errInvalidClockTime
+		}
+		nums[i] = n
+	}
+	if nums[1] > 59 || nums[2] > 59 {
+		return 0, errInvalidClockTime
+	}
+	if nums[0] > 24 || (nums[0] == 24 && (nums[1] != 0 || nums[2] != 0)) {
+		return 0, errInvalidClockTime
+	}
+	d := time.Duration(nums[0])*time.Hour + time.Duration(nums[1])*time.Minute + time.Duration(nums[2])*time.Second
+	return ClockTime(d), nil
+}
+
+// ClockTimeOf return the clock time of t.
+func ClockTimeOf(t time.Time) ClockTime {
+	return ClockTime(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond()))
+}
+
+// String return the clock time in "15:04" or "15:04:05" format.
+func (c ClockTime) String() string {
+	sec := int(time.Duration(c) / time.Second)
+	if sec%60 != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", sec/3600, sec/60%60, sec%60)
+	}
+	return fmt.Sprintf("%02d:%02d", sec/3600, sec/60%60)
+}
+
+type TimeOfDayFilter struct {
+	validators []TimeOfDayValidator
+	allowVals  allowList
+}
+
+type TimeOfDayValidator func(paramName string, paramValue ClockTime) *Error
+
+// TimeOfDay return a time of day filter, it accepts "15:04" or "15:04:05"
+// and output ClockTime.
+func TimeOfDay() *TimeOfDayFilter {
+	f := new(TimeOfDayFilter)
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *TimeOfDayFilter) Allow(vals ...string) *TimeOfDayFilter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *TimeOfDayFilter) AllowSpecial(special Special, vals ...string) *TimeOfDayFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *TimeOfDayFilter) AllowSpecialFold(special Special, vals ...string) *TimeOfDayFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *TimeOfDayFilter) AddValidator(validator TimeOfDayValidator) *TimeOfDayFilter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// StartFrom valid whether start from specified time of day.
+func (f *TimeOfDayFilter) StartFrom(tm string) *TimeOfDayFilter {
+	f.AddValidator(func(paramName string, paramValue ClockTime) *Error {
+		t, err := ParseClockTime(tm)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if paramValue < t {
+			return NewError(ErrorInvalidParam, paramName, "TooEarly")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// EndTo valid whether end to specified time of day.
+func (f *TimeOfDayFilter) EndTo(tm string) *TimeOfDayFilter {
+	f.AddValidator(func(paramName string, paramValue ClockTime) *Error {
+		t, err := ParseClockTime(tm)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if paramValue > t {
+			return NewError(ErrorInvalidParam, paramName, "TooLate")
+		}
+
+		return nil
+	})
+	return f
+}
+
+// Between valid whether between two times of day.
+func (f *TimeOfDayFilter) Between(startTime, endTime string) *TimeOfDayFilter {
+	f.StartFrom(startTime)
+	f.EndTo(endTime)
+	return f
+}
+
+// Run make the filter running.
+func (f *TimeOfDayFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var clockVal ClockTime
+	switch val := paramValue.(type) {
+	case string:
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		c, err := ParseClockTime(val)
+		if err != nil {
+			goto parse_error
+		}
+		clockVal = c
+	case ClockTime:
+		clockVal = val
+	case time.Time:
+		clockVal = ClockTimeOf(val)
+	default:
+		goto parse_error
+	}
+
+	for _, validator := range f.validators {
+		if err := validator(paramName, clockVal); err != nil {
+			return nil, err
+		}
+	}
+
+	return clockVal, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotTimeOfDay")
+}
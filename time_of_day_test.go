@@ -0,0 +1,94 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseClockTime(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"09:30", "09:30", true},
+		{"23:59:59", "23:59:59", true},
+		{"24:00", "24:00", true},
+		{"24:00:01", "", false},
+		{"9:30", "", false},
+		{"12:60", "", false},
+		{"25:00", "", false},
+		{"12", "", false},
+	}
+	for _, tt := range tests {
+		got, err := ParseClockTime(tt.in)
+		if (err == nil) != tt.ok || (err == nil && got.String() != tt.want) {
+			t.Errorf("ParseClockTime(%q) = %v, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestTimeOfDayFilter(t *testing.T) {
+	testRun(t, []runCase{
+		{TimeOfDay(), "08:15", "08:15", ""},
+		{TimeOfDay(), time.Date(2024, 1, 1, 13, 5, 7, 0, timeLoc), "13:05:07", ""},
+		{TimeOfDay(), "8am", "", "NotTimeOfDay"},
+		{TimeOfDay().StartFrom("09:00"), "08:59", "", "TooEarly"},
+		{TimeOfDay().EndTo("18:00"), "18:00:01", "", "TooLate"},
+		{TimeOfDay().Between("09:00", "18:00"), "12:00", "12:00", ""},
+	})
+}
+
+func TestTimeOfDayRangeFilter(t *testing.T) {
+	testRun(t, []runCase{
+		{TimeOfDayRange(), "09:00-18:00", "09:00-18:00", ""},
+		{TimeOfDayRange(), "22:00~02:00", "22:00-02:00", ""},
+		{TimeOfDayRange().NoWrap(), "22:00-02:00", "", "WrongRange"},
+		{TimeOfDayRange(), "09:00-09:00", "", "NotTimeOfDayRange"},
+		{TimeOfDayRange().MinLength(time.Hour), "09:00-09:30", "", "TooShort"},
+		{TimeOfDayRange().MaxLength(4 * time.Hour), "22:00-03:00", "", "TooLong"},
+		{TimeOfDayRange().Within("20:00-06:00"), "22:00-02:00", "22:00-02:00", ""},
+		{TimeOfDayRange().Within("20:00-06:00"), "05:00-07:00", "", "OutOfRange"},
+	})
+
+	r, _ := ParseClockRange("22:00-02:00")
+	c, _ := ParseClockTime("01:00")
+	if !r.Wraps() || r.Length() != 4*time.Hour || !r.Contains(c) {
+		t.Errorf("ClockRange %v: wraps %v length %v", r, r.Wraps(), r.Length())
+	}
+}
+
+func TestWeekdaySetFilter(t *testing.T) {
+	testRun(t, []runCase{
+		{Weekdays(), "mon,wed,FRI", "[Monday Wednesday Friday]", ""},
+		{Weekdays(), "mon-fri", "[Monday Tuesday Wednesday Thursday Friday]", ""},
+		{Weekdays(), "fri-mon", "[Friday Saturday Sunday Monday]", ""},
+		{Weekdays(), "0,7", "[Sunday Sunday]", ""},
+		{Weekdays(), "funday", "", "NotWeekdaySet"},
+		{Weekdays(), "8", "", "NotWeekdaySet"},
+		{Weekdays().MaxCount(2), "mon-wed", "", "TooMany"},
+		{Weekdays().ItemIn(time.Monday, time.Tuesday), "mon,sun", "", "ItemNotInSet"},
+	})
+}
+
+func TestWeeklyScheduleFilter(t *testing.T) {
+	testRun(t, []runCase{
+		{WeeklySchedule(), "mon 09:00-18:00; mon 17:00-19:00", "", "SlotsOverlap"},
+		{WeeklySchedule(), "sun 22:00-02:00; mon 01:00-03:00", "", "SlotsOverlap"},
+		{WeeklySchedule().MinSlot(time.Hour), "mon 09:00-09:30", "", "SlotTooShort"},
+		{WeeklySchedule(), "monday", "", "NotWeeklySchedule"},
+		{WeeklySchedule(), "xyz 09:00-10:00", "", "NotWeeklySchedule"},
+	})
+
+	got, err := WeeklySchedule().Run("p", "mon-fri 09:00-12:00,13:00-18:00; sat 22:00-02:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	slots := got.([]*WeeklySlot)
+	if len(slots) != 11 || slots[10].Weekday != time.Saturday || slots[10].Range.String() != "22:00-02:00" {
+		t.Errorf("WeeklySchedule slots = %d", len(slots))
+	}
+	if _, err := WeeklySchedule().AllowOverlap().Run("p", "mon 09:00-18:00; mon 17:00-19:00"); err != nil {
+		t.Errorf("AllowOverlap error = %v", err)
+	}
+}
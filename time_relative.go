@@ -0,0 +1,185 @@
+package filter
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidRelativeTime = errors.New("invalid relative time")
+
+var calendarUnitRegexp = regexp.MustCompile(`^([0-9]+)(d|w|M|Q|y)`)
+
+var relativeOffsetRegexp = regexp.MustCompile(`^([+-])([0-9]+)(s|m|h|d|w|M|Q|y)`)
+
+// relative time bases, evaluated in the location of t
+var relativeTimeBases = map[string]func(t time.Time) time.Time{
+	"now": func(t time.Time) time.Time {
+		return t
+	},
+	"today": func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	},
+	"startOfWeek": func(t time.Time) time.Time {
+		// week starts from monday
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, t.Location())
+	},
+	"startOfMonth": func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	},
+	"startOfQuarter": func(t time.Time) time.Time {
+		month := (t.Month()-1)/3*3 + 1
+		return time.Date(t.Year(), month, 1, 0, 0, 0, 0, t.Location())
+	},
+	"startOfYear": func(t time.Time) time.Time {
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	},
+}
+
+// IsRelativeTime check whether expr is a relative time expression.
+func IsRelativeTime(expr string) bool {
+	base, _ := splitRelativeTime(expr)
+	_, ok := relativeTimeBases[base]
+	return ok
+}
+
+// ParseRelativeTime evaluate a relative time expression at now in loc.
+// Expression is a base followed by zero or more offsets, eg: "now",
+// "now-90d", "today+1d", "startOfMonth+1M-1s". Bases are now, today,
+// startOfWeek, startOfMonth, startOfQuarter and startOfYear. Offset units are
+// s, m (minute), h, d, w, M (month), Q (quarter) and y.
+func ParseRelativeTime(expr string, now time.Time, loc *time.Location) (time.Time, error) {
+	base, offsets := splitRelativeTime(expr)
+	baseFunc, ok := relativeTimeBases[base]
+	if !ok {
+		return time.Time{}, errInvalidRelativeTime
+	}
+
+	t := baseFunc(now.In(loc))
+	for offsets != "" {
+		m := relativeOffsetRegexp.FindStringSubmatch(offsets)
+		if m == nil {
+			return time.Time{}, errInvalidRelativeTime
+		}
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return time.Time{}, errInvalidRelativeTime
+		}
+		if m[1] == "-" {
+			n = -n
+		}
+		switch m[3] {
+		case "s":
+			t = t.Add(time.Duration(n) * time.Second)
+		case "m":
+			t = t.Add(time.Duration(n) * time.Minute)
+		case "h":
+			t = t.Add(time.Duration(n) * time.Hour)
+		case "d":
+			t = t.AddDate(0, 0, n)
+		case "w":
+			t = t.AddDate(0, 0, 7*n)
+		case "M":
+			t = t.AddDate(0, n, 0)
+		case "Q":
+			t = t.AddDate(0, 3*n, 0)
+		case "y":
+			t = t.AddDate(n, 0, 0)
+		}
+		offsets = offsets[len(m[0]):]
+	}
+	return t, nil
+}
+
+// splitRelativeTime split expr into base and offsets part.
+func splitRelativeTime(expr string) (string, string) {
+	expr = strings.Replace(expr, " ", "", -1)
+	if i := strings.IndexAny(expr, "+-"); i >= 0 {
+		return expr[:i], expr[i:]
+	}
+	return expr, ""
+}
+
+// parseTimeBound parse a time bound of validator, it can be a relative
+// time expression evaluated at now, or a time in one of the layouts.
+func parseTimeBound(layouts []string, value string, loc *time.Location) (time.Time, error) {
+	if IsRelativeTime(value) {
+		return ParseRelativeTime(value, now(), loc)
+	}
+	return parseTime(layouts, value, loc)
+}
+
+// parseTimestampBound evaluate a relative time expression as unix timestamp.
+func parseTimestampBound(expr string) (int64, error) {
+	t, err := ParseRelativeTime(expr, now(), timeLoc)
+	if err != nil {
+		return 0, err
+	}
+	return t.Unix(), nil
+}
+
+// parseCalendarDistance parse a calendar distance like "3M", "1y6M" or "2w"
+// into years, months and days. Units are d, w, M (month), Q (quarter) and y.
+func parseCalendarDistance(expr string) (years, months, days int, err error) {
+	expr = strings.Replace(expr, " ", "", -1)
+	if expr == "" {
+		return 0, 0, 0, errInvalidRelativeTime
+	}
+	for expr != "" {
+		m := calendarUnitRegexp.FindStringSubmatch(expr)
+		if m == nil {
+			return 0, 0, 0, errInvalidRelativeTime
+		}
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return 0, 0, 0, errInvalidRelativeTime
+		}
+		switch m[2] {
+		case "d":
+			days += n
+		case "w":
+			days += 7 * n
+		case "M":
+			months += n
+		case "Q":
+			months += 3 * n
+		case "y":
+			years += n
+		}
+		expr = expr[len(m[0]):]
+	}
+	return years, months, days, nil
+}
+
+// compareCalendarDistance compare the distance from left to right with a
+// calendar distance expression, days are counted on the wall clock of loc so
+// DST changes do not matter. It returns -1, 0 or 1.
+func compareCalendarDistance(left, right time.Time, expr string, loc *time.Location) (int, error) {
+	years, months, days, err := parseCalendarDistance(expr)
+	if err != nil {
+		return 0, err
+	}
+	end := addCalendarMonths(left.In(loc), 12*years+months).AddDate(0, 0, days)
+	switch {
+	case right.Before(end):
+		return -1, nil
+	case right.After(end):
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// addCalendarMonths add n months to t, the day is clamped to the end of the
+// target month, eg: Jan 31 plus one month is Feb 28 or 29.
+func addCalendarMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return first.AddDate(0, 0, day-1)
+}
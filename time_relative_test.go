@@ -0,0 +1,84 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRelativeTime(t *testing.T) {
+	// a thursday
+	now := time.Date(2024, 5, 16, 10, 30, 45, 0, timeLoc)
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"now", "2024-05-16 10:30:45"},
+		{"now-90d", "2024-02-16 10:30:45"},
+		{"now - 1h + 30m", "2024-05-16 10:00:45"},
+		{"today", "2024-05-16 00:00:00"},
+		{"today+1d", "2024-05-17 00:00:00"},
+		{"startOfWeek", "2024-05-13 00:00:00"},
+		{"startOfMonth+1M-1s", "2024-05-31 23:59:59"},
+		{"startOfQuarter", "2024-04-01 00:00:00"},
+		{"startOfYear-1Q", "2023-10-01 00:00:00"},
+		{"now+2w", "2024-05-30 10:30:45"},
+		{"now-1y", "2023-05-16 10:30:45"},
+		{"yesterday", ""},
+		{"now+1x", ""},
+		{"now+", ""},
+	}
+	for _, tt := range tests {
+		got, err := ParseRelativeTime(tt.expr, now, timeLoc)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ParseRelativeTime(%q) = %v, want error", tt.expr, got)
+			}
+			continue
+		}
+		if err != nil || got.Format(LayoutDateTime) != tt.want {
+			t.Errorf("ParseRelativeTime(%q) = %v, %v, want %s", tt.expr, got, err, tt.want)
+		}
+	}
+
+	if !IsRelativeTime("today-1d") || IsRelativeTime("2024-01-01") {
+		t.Error("IsRelativeTime mismatch")
+	}
+}
+
+func TestCompareCalendarDistance(t *testing.T) {
+	jan31 := time.Date(2024, 1, 31, 0, 0, 0, 0, timeLoc)
+	tests := []struct {
+		right string
+		expr  string
+		want  int
+	}{
+		{"2024-02-29", "1M", 0},
+		{"2024-02-28", "1M", -1},
+		{"2024-03-01", "1M", 1},
+		{"2025-01-31", "1y", 0},
+		{"2024-02-14", "2w", 0},
+		{"2024-07-31", "1Q1M2M", 0},
+	}
+	for _, tt := range tests {
+		right, _ := time.ParseInLocation(LayoutDate, tt.right, timeLoc)
+		got, err := compareCalendarDistance(jan31, right, tt.expr, timeLoc)
+		if err != nil || got != tt.want {
+			t.Errorf("compareCalendarDistance(%s, %q) = %d, %v, want %d", tt.right, tt.expr, got, err, tt.want)
+		}
+	}
+	if _, err := compareCalendarDistance(jan31, jan31, "1h", timeLoc); err == nil {
+		t.Error("compareCalendarDistance(1h) want error")
+	}
+}
+
+func TestRelativeTimeBounds(t *testing.T) {
+	testRun(t, []runCase{
+		{Time().StartFrom("now-1d"), "2000-01-01", "", "TooEarly"},
+		{Time().EndTo("now+1d"), "2999-01-01", "", "TooLate"},
+		{Time().Between("today-100y", "today+100y"), "2024-01-01", "2024-01-01 00:00:00", ""},
+		{Time().StartFrom("now-1x"), "2024-01-01", "", "InvalidValidator"},
+		{Timestamp().StartFromRelative("now-1d"), "946684800", "", "TooEarly"},
+		{Timestamp().BeforeRelative("now"), "946684800", "946684800", ""},
+		{TimeSet().ItemEndTo("now"), "2000-01-01,2999-01-01", "", "ItemTooLate"},
+	})
+}
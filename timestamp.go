@@ -1,13 +1,20 @@
 package filter
 
 import (
+	"math"
 	"strconv"
 	"strings"
 )
 
 type TimestampFilter struct {
+	strict     strictMode
+	clamp      bool
+	clampMin   uint32
+	clampMax   uint32
+	clampErr   error
+	clampHook  ClampHook
 	validators []TimestampValidator
-	allowVals  []string
+	allowVals  allowList
 }
 
 type TimestampValidator func(paramName string, paramValue uint32) *Error
@@ -18,9 +25,56 @@ func Timestamp() *TimestampFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *TimestampFilter) Allow(vals ...string) *TimestampFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *TimestampFilter) AllowSpecial(special Special, vals ...string) *TimestampFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *TimestampFilter) AllowSpecialFold(special Special, vals ...string) *TimestampFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Clamp move param value out of [min, max] onto the nearest limit instead of
+// failing, validators run on the adjusted value.
+func (f *TimestampFilter) Clamp(min, max uint32) *TimestampFilter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *TimestampFilter) OnClamp(hook ClampHook) *TimestampFilter {
+	f.clampHook = hook
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *TimestampFilter) Strict() *TimestampFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *TimestampFilter) Lenient() *TimestampFilter {
+	f.strict = strictOff
 	return f
 }
 
@@ -103,6 +157,112 @@ func (f *TimestampFilter) Between(start, end uint32) *TimestampFilter {
 	return f
 }
 
+// StartFromRelative valid whether param value is start from the time of relative
+// expression, eg: "now", "today-90d". The expression is evaluated on each run.
+func (f *TimestampFilter) StartFromRelative(expr string) *TimestampFilter {
+	f.AddValidator(func(paramName string, paramValue uint32) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if int64(paramValue) < t {
+			return NewError(ErrorInvalidParam, paramName, "TooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// EndToRelative valid whether param value is end to the time of relative
+// expression, eg: "now", "today-90d". The expression is evaluated on each run.
+func (f *TimestampFilter) EndToRelative(expr string) *TimestampFilter {
+	f.AddValidator(func(paramName string, paramValue uint32) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if int64(paramValue) > t {
+			return NewError(ErrorInvalidParam, paramName, "TooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// AfterRelative valid whether param value is after the time of relative
+// expression, eg: "now", "today-90d". The expression is evaluated on each run.
+func (f *TimestampFilter) AfterRelative(expr string) *TimestampFilter {
+	f.AddValidator(func(paramName string, paramValue uint32) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if int64(paramValue) <= t {
+			return NewError(ErrorInvalidParam, paramName, "TooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// BeforeRelative valid whether param value is before the time of relative
+// expression, eg: "now", "today-90d". The expression is evaluated on each run.
+func (f *TimestampFilter) BeforeRelative(expr string) *TimestampFilter {
+	f.AddValidator(func(paramName string, paramValue uint32) *Error {
+		t, err := parseTimestampBound(expr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if int64(paramValue) >= t {
+			return NewError(ErrorInvalidParam, paramName, "TooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// BetweenRelative valid whether param value is between the times of two
+// relative expressions. The expressions are evaluated on each run.
+func (f *TimestampFilter) BetweenRelative(startExpr, endExpr string) *TimestampFilter {
+	f.AddValidator(func(paramName string, paramValue uint32) *Error {
+		start, err := parseTimestampBound(startExpr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		end, err := parseTimestampBound(endExpr)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if int64(paramValue) < start {
+			return NewError(ErrorInvalidParam, paramName, "TooEarly")
+		}
+		if int64(paramValue) > end {
+			return NewError(ErrorInvalidParam, paramName, "TooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// clampValue move v onto the nearest limit of clamp range.
+func (f *TimestampFilter) clampValue(paramName string, v uint32) uint32 {
+	adjusted := v
+	if v < f.clampMin {
+		adjusted = f.clampMin
+	} else if v > f.clampMax {
+		adjusted = f.clampMax
+	}
+	if adjusted != v && f.clampHook != nil {
+		f.clampHook(paramName, v, adjusted)
+	}
+	return adjusted
+}
+
 // Run make the filter running.
 func (f *TimestampFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -112,23 +272,38 @@ func (f *TimestampFilter) Run(paramName string, paramValue interface{}) (interfa
 	var intVal uint32
 	switch val := paramValue.(type) {
 	case string:
+		raw := val
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
-			}
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
 		}
-		v, err := strconv.ParseUint(val, 10, 0)
-		if err != nil {
+		if word := f.strict.check(raw, 10, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		v, err := strconv.ParseUint(val, 10, 32)
+		if err != nil && !(f.clamp && isRangeErr(err)) {
 			goto parse_error
 		}
 		intVal = uint32(v)
 	case uint32:
 		intVal = val
 	default:
-		goto parse_error
+		v, word, ok := coerceUint64(val, math.MaxUint32)
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		intVal = uint32(v)
 	}
 
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		intVal = f.clampValue(paramName, intVal)
+	}
 	for _, validator := range f.validators {
 		if err := validator(paramName, intVal); err != nil {
 			return nil, err
@@ -0,0 +1,390 @@
+package filter
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Timestamp64Filter struct {
+	strict     strictMode
+	unit       TimestampUnit
+	clamp      bool
+	clampMin   int64
+	clampMax   int64
+	clampErr   error
+	clampHook  ClampHook
+	validators []Timestamp64Validator
+	allowVals  allowList
+}
+
+type Timestamp64Validator func(paramName string, paramValue int64) *Error
+
+// TimestampUnit is the unit of a int64 timestamp.
+type TimestampUnit time.Duration
+
+// timestamp units
+const (
+	TimestampSecond = TimestampUnit(time.Second)
+	TimestampMilli  = TimestampUnit(time.Millisecond)
+	TimestampMicro  = TimestampUnit(time.Microsecond)
+	TimestampNano   = TimestampUnit(time.Nanosecond)
+)
+
+var errTimestampOverflow = errors.New("timestamp overflow")
+
+// Timestamp64 return a int64 timestamp filter, the unit is second by default.
+// Time bounds of Relative validators are relative time expressions like
+// "now-90d", see ParseRelativeTime.
+func Timestamp64() *Timestamp64Filter {
+	f := new(Timestamp64Filter)
+	f.unit = TimestampSecond
+	return f
+}
+
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
+func (f *Timestamp64Filter) Allow(vals ...string) *Timestamp64Filter {
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *Timestamp64Filter) AllowSpecial(special Special, vals ...string) *Timestamp64Filter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *Timestamp64Filter) AllowSpecialFold(special Special, vals ...string) *Timestamp64Filter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
+	return f
+}
+
+// Unit set the unit of timestamp, eg: TimestampMilli for javascript timestamps.
+func (f *Timestamp64Filter) Unit(unit TimestampUnit) *Timestamp64Filter {
+	f.unit = unit
+	return f
+}
+
+// Clamp move param value out of [min, max] onto the nearest limit instead of
+// failing, validators run on the adjusted value.
+func (f *Timestamp64Filter) Clamp(min, max int64) *Timestamp64Filter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *Timestamp64Filter) OnClamp(hook ClampHook) *Timestamp64Filter {
+	f.clampHook = hook
+	return f
+}
+
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *Timestamp64Filter) Strict() *Timestamp64Filter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *Timestamp64Filter) Lenient() *Timestamp64Filter {
+	f.strict = strictOff
+	return f
+}
+
+// AddValidator add a custom validator to filter
+func (f *Timestamp64Filter) AddValidator(validator Timestamp64Validator) *Timestamp64Filter {
+	f.validators = append(f.validators, validator)
+	return f
+}
+
+// StartFrom valid whether param value is start from specified time.
+func (f *Timestamp64Filter) StartFrom(val int64) *Timestamp64Filter {
+	f.AddValidator(func(paramName string, paramValue int64) *Error {
+		if paramValue < val {
+			return NewError(ErrorInvalidParam, paramName, "TooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// EndTo valid whether param value is end to specified time.
+func (f *Timestamp64Filter) EndTo(val int64) *Timestamp64Filter {
+	f.AddValidator(func(paramName string, paramValue int64) *Error {
+		if paramValue > val {
+			return NewError(ErrorInvalidParam, paramName, "TooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// After valid whether param value is after specified time.
+func (f *Timestamp64Filter) After(val int64) *Timestamp64Filter {
+	f.AddValidator(func(paramName string, paramValue int64) *Error {
+		if paramValue <= val {
+			return NewError(ErrorInvalidParam, paramName, "TooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// Before valid whether param value is before specified time.
+func (f *Timestamp64Filter) Before(val int64) *Timestamp64Filter {
+	f.AddValidator(func(paramName string, paramValue int64) *Error {
+		if paramValue >= val {
+			return NewError(ErrorInvalidParam, paramName, "TooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// Equal valid whether param value is equal to specified time.
+func (f *Timestamp64Filter) Equal(val int64) *Timestamp64Filter {
+	f.AddValidator(func(paramName string, paramValue int64) *Error {
+		if paramValue < val {
+			return NewError(ErrorInvalidParam, paramName, "TooEarly")
+		}
+		if paramValue > val {
+			return NewError(ErrorInvalidParam, paramName, "TooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// Between valid whether param value is in the specified range.
+func (f *Timestamp64Filter) Between(start, end int64) *Timestamp64Filter {
+	f.AddValidator(func(paramName string, paramValue int64) *Error {
+		if paramValue < start {
+			return NewError(ErrorInvalidParam, paramName, "TooEarly")
+		}
+		if paramValue > end {
+			return NewError(ErrorInvalidParam, paramName, "TooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// StartFromRelative valid whether param value is start from the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64Filter) StartFromRelative(expr string) *Timestamp64Filter {
+	f.AddValidator(func(paramName string, paramValue int64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if paramValue < t {
+			return NewError(ErrorInvalidParam, paramName, "TooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// EndToRelative valid whether param value is end to the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64Filter) EndToRelative(expr string) *Timestamp64Filter {
+	f.AddValidator(func(paramName string, paramValue int64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if paramValue > t {
+			return NewError(ErrorInvalidParam, paramName, "TooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// AfterRelative valid whether param value is after the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64Filter) AfterRelative(expr string) *Timestamp64Filter {
+	f.AddValidator(func(paramName string, paramValue int64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if paramValue <= t {
+			return NewError(ErrorInvalidParam, paramName, "TooEarly")
+		}
+		return nil
+	})
+	return f
+}
+
+// BeforeRelative valid whether param value is before the time of
+// relative expression. The expression is evaluated on each run.
+func (f *Timestamp64Filter) BeforeRelative(expr string) *Timestamp64Filter {
+	f.AddValidator(func(paramName string, paramValue int64) *Error {
+		t, err := parseTimestamp64Bound(expr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if paramValue >= t {
+			return NewError(ErrorInvalidParam, paramName, "TooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// BetweenRelative valid whether param value is between the times of
+// two relative expressions. The expressions are evaluated on each run.
+func (f *Timestamp64Filter) BetweenRelative(startExpr, endExpr string) *Timestamp64Filter {
+	f.AddValidator(func(paramName string, paramValue int64) *Error {
+		start, err := parseTimestamp64Bound(startExpr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		end, err := parseTimestamp64Bound(endExpr, f.unit)
+		if err != nil {
+			return NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+
+		if paramValue < start {
+			return NewError(ErrorInvalidParam, paramName, "TooEarly")
+		}
+		if paramValue > end {
+			return NewError(ErrorInvalidParam, paramName, "TooLate")
+		}
+		return nil
+	})
+	return f
+}
+
+// clampValue move v onto the nearest limit of clamp range.
+func (f *Timestamp64Filter) clampValue(paramName string, v int64) int64 {
+	adjusted := v
+	if v < f.clampMin {
+		adjusted = f.clampMin
+	} else if v > f.clampMax {
+		adjusted = f.clampMax
+	}
+	if adjusted != v && f.clampHook != nil {
+		f.clampHook(paramName, v, adjusted)
+	}
+	return adjusted
+}
+
+// Run make the filter running.
+func (f *Timestamp64Filter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
+	if paramValue == nil {
+		return nil, nil
+	}
+
+	var tsVal int64
+	switch val := paramValue.(type) {
+	case string:
+		raw := val
+		val = strings.Trim(val, " \t\r\n")
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.check(raw, 10, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		v, err := parseTimestamp64(val)
+		if err == errTimestampOverflow && f.clamp {
+			err = nil
+		}
+		if err == errTimestampOverflow {
+			return nil, NewError(ErrorInvalidParam, paramName, "TimestampOverflow")
+		}
+		if err != nil {
+			goto parse_error
+		}
+		tsVal = v
+	case int64:
+		tsVal = val
+	case int:
+		tsVal = int64(val)
+	case uint32:
+		tsVal = int64(val)
+	case time.Time:
+		v, err := timestampOf(val, f.unit)
+		if err != nil {
+			return nil, NewError(ErrorInvalidParam, paramName, "TimestampOverflow")
+		}
+		tsVal = v
+	default:
+		v, word, ok := coerceInt64(val, math.MinInt64, math.MaxInt64)
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		tsVal = v
+	}
+
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		tsVal = f.clampValue(paramName, tsVal)
+	}
+	for _, validator := range f.validators {
+		if err := validator(paramName, tsVal); err != nil {
+			return nil, err
+		}
+	}
+
+	return tsVal, nil
+
+parse_error:
+	return nil, NewError(ErrorInvalidParam, paramName, "NotTimestamp64")
+}
+
+// parseTimestamp64 parse a int64 timestamp string, values out of int64 range
+// return errTimestampOverflow instead of being truncated.
+func parseTimestamp64(val string) (int64, error) {
+	v, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return v, errTimestampOverflow
+		}
+		return 0, err
+	}
+	return v, nil
+}
+
+// timestampOf return the timestamp of t in the specified unit.
+func timestampOf(t time.Time, unit TimestampUnit) (int64, error) {
+	perSecond := int64(time.Second) / int64(unit)
+	sec := t.Unix()
+	if sec > math.MaxInt64/perSecond-1 || sec < math.MinInt64/perSecond+1 {
+		return 0, errTimestampOverflow
+	}
+	return sec*perSecond + int64(t.Nanosecond())/int64(unit), nil
+}
+
+// parseTimestamp64Bound evaluate a relative time expression as timestamp in
+// the specified unit.
+func parseTimestamp64Bound(expr string, unit TimestampUnit) (int64, error) {
+	t, err := ParseRelativeTime(expr, now(), timeLoc)
+	if err != nil {
+		return 0, err
+	}
+	return timestampOf(t, unit)
+}
@@ -0,0 +1,66 @@
+package filter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimestamp64Filter(t *testing.T) {
+	testRun(t, []runCase{
+		{Timestamp64(), "1700000000000", "1700000000000", ""},
+		{Timestamp64(), "-1", "-1", ""},
+		{Timestamp64(), "99999999999999999999", "", "TimestampOverflow"},
+		{Timestamp64(), "1.5", "", "NotTimestamp64"},
+		{Timestamp64().Unit(TimestampMilli), time.Unix(1700000000, 5e6), "1700000000005", ""},
+		{Timestamp64().Unit(TimestampNano), time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC), "", "TimestampOverflow"},
+		{Timestamp64().StartFrom(100), "99", "", "TooEarly"},
+		{Timestamp64().Before(100), "100", "", "TooLate"},
+		{Timestamp64().Clamp(0, 100), "200", "100", ""},
+		{Timestamp64Set(), "3,1,2", "[3 1 2]", ""},
+		{Timestamp64Set().ItemAfter(1), "3,1", "", "ItemTooEarly"},
+	})
+}
+
+func TestTimestamp64RangeFilter(t *testing.T) {
+	testRun(t, []runCase{
+		{Timestamp64Range(), "[100,200)", "[100,200)", ""},
+		{Timestamp64Range(), "200,100", "", "NotTimestamp64Range"},
+		{Timestamp64Range(), "[1,99999999999999999999]", "", "TimestampOverflow"},
+		{Timestamp64Range().LeftDefault(10).RightDefault(20), "[,15]", "[10,15]", ""},
+		{Timestamp64Range(), []interface{}{1, 2}, "[1,2]", ""},
+		{Timestamp64Range().LeftStartFrom(100), "(99,200]", "(99,200]", ""},
+		{Timestamp64Range().RightEndTo(200), "[100,201)", "[100,201)", ""},
+		{Timestamp64Range().RightEndTo(200), "[100,202)", "", "RightTooLate"},
+		{Timestamp64Range().MaxDistance(10), "[0,11]", "", "TooFar"},
+	})
+
+	// bounds of validators must not drift between runs
+	validators := []struct {
+		f    *Timestamp64RangeFilter
+		in   string
+		word string
+	}{
+		{Timestamp64Range().LeftStartFrom(100), "(98,200]", "LeftTooEarly"},
+		{Timestamp64Range().LeftEndTo(100), "(100,200]", "LeftTooLate"},
+		{Timestamp64Range().LeftAfter(100), "(99,200]", "LeftTooEarly"},
+		{Timestamp64Range().LeftBefore(100), "(99,200]", "LeftTooLate"},
+		{Timestamp64Range().LeftEqual(100), "(98,200]", "LeftTooEarly"},
+		{Timestamp64Range().RightStartFrom(100), "[0,98)", "RightTooEarly"},
+		{Timestamp64Range().RightEndTo(100), "[0,102)", "RightTooLate"},
+		{Timestamp64Range().RightAfter(100), "[0,101)", "RightTooEarly"},
+		{Timestamp64Range().RightBefore(100), "[0,101)", "RightTooLate"},
+		{Timestamp64Range().RightEqual(100), "[0,102)", "RightTooLate"},
+		{Timestamp64Range().LeftBetween(100, 200), "(97,300]", "LeftTooEarly"},
+		{Timestamp64Range().LeftBetween(100, 200), "(200,300]", "LeftTooLate"},
+		{Timestamp64Range().RightBetween(100, 200), "[0,203)", "RightTooLate"},
+		{Timestamp64Range().RightBetween(100, 200), "[0,100)", "RightTooEarly"},
+	}
+	for _, tt := range validators {
+		for run := 0; run < 5; run++ {
+			_, err := tt.f.Run("p", tt.in)
+			if errWord(err) != tt.word {
+				t.Errorf("run %d of %s = %v, want %s", run, tt.in, err, tt.word)
+			}
+		}
+	}
+}
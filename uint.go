@@ -3,12 +3,23 @@ package filter
 import (
 	"strconv"
 	"strings"
+
+	"github.com/go-apibox/types"
 )
 
 type UintFilter struct {
-	base       int
-	validators []UintValidator
-	allowVals  []string
+	base            int
+	strict          strictMode
+	clamp           bool
+	clampMin        uint
+	clampMax        uint
+	clampErr        error
+	clampHook       ClampHook
+	units           NumberUnits
+	locale          *NumberLocale
+	chineseNumerals bool
+	validators      []UintValidator
+	allowVals       allowList
 }
 
 type UintValidator func(paramName string, paramValue uint) *Error
@@ -20,9 +31,24 @@ func Uint() *UintFilter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *UintFilter) Allow(vals ...string) *UintFilter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *UintFilter) AllowSpecial(special Special, vals ...string) *UintFilter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *UintFilter) AllowSpecialFold(special Special, vals ...string) *UintFilter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -35,6 +61,59 @@ func (f *UintFilter) Base(base int) *UintFilter {
 	return f
 }
 
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *UintFilter) Strict() *UintFilter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *UintFilter) Lenient() *UintFilter {
+	f.strict = strictOff
+	return f
+}
+
+// Clamp move param value out of [min, max] onto the nearest limit instead of
+// failing, validators run on the adjusted value.
+func (f *UintFilter) Clamp(min, max uint) *UintFilter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *UintFilter) OnClamp(hook ClampHook) *UintFilter {
+	f.clampHook = hook
+	return f
+}
+
+// Units allow values with unit suffixes like "10MB" or "80%", values are
+// normalized to the base unit before validation, see ByteUnits, SIUnits and
+// PercentUnits.
+func (f *UintFilter) Units(units ...NumberUnits) *UintFilter {
+	f.units = f.units.merge(units...)
+	return f
+}
+
+// Locale accept numbers with the decimal and grouping separators of locale,
+// full-width digits are folded too, eg: "1.234,56" with NumberLocaleDE.
+func (f *UintFilter) Locale(locale *NumberLocale) *UintFilter {
+	f.locale = locale
+	return f
+}
+
+// ChineseNumerals accept Chinese numerals like "一百二十" and "三点五".
+func (f *UintFilter) ChineseNumerals() *UintFilter {
+	f.chineseNumerals = true
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *UintFilter) AddValidator(validator UintValidator) *UintFilter {
 	f.validators = append(f.validators, validator)
@@ -127,6 +206,20 @@ func (f *UintFilter) In(set []uint) *UintFilter {
 	return f
 }
 
+// clampValue move v onto the nearest limit of clamp range.
+func (f *UintFilter) clampValue(paramName string, v uint) uint {
+	adjusted := v
+	if v < f.clampMin {
+		adjusted = f.clampMin
+	} else if v > f.clampMax {
+		adjusted = f.clampMax
+	}
+	if adjusted != v && f.clampHook != nil {
+		f.clampHook(paramName, v, adjusted)
+	}
+	return adjusted
+}
+
 // Run make the filter running.
 func (f *UintFilter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -136,23 +229,55 @@ func (f *UintFilter) Run(paramName string, paramValue interface{}) (interface{},
 	var intVal uint
 	switch val := paramValue.(type) {
 	case string:
+		raw := val
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.check(raw, f.base, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		if f.locale != nil || f.chineseNumerals {
+			var ok bool
+			if val, ok = normalizeNumber(val, f.locale, f.chineseNumerals); !ok {
+				goto parse_error
+			}
+		}
+		if r, matched, ok := f.units.parse(val); matched {
+			if !ok {
+				goto parse_error
+			}
+			v, word, _ := coerceUint64(r, uint64(types.MaxUint))
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, word)
 			}
+			intVal = uint(v)
+			break
 		}
 		v, err := strconv.ParseUint(val, f.base, 0)
-		if err != nil {
+		if err != nil && !(f.clamp && isRangeErr(err)) {
 			goto parse_error
 		}
 		intVal = uint(v)
 	case uint:
 		intVal = val
 	default:
-		goto parse_error
+		v, word, ok := coerceUint64(val, uint64(types.MaxUint))
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		intVal = uint(v)
 	}
 
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		intVal = f.clampValue(paramName, intVal)
+	}
 	for _, validator := range f.validators {
 		if err := validator(paramName, intVal); err != nil {
 			return nil, err
@@ -1,14 +1,24 @@
 package filter
 
 import (
+	"math"
 	"strconv"
 	"strings"
 )
 
 type Uint64Filter struct {
-	base       int
-	validators []Uint64Validator
-	allowVals  []string
+	base            int
+	strict          strictMode
+	clamp           bool
+	clampMin        uint64
+	clampMax        uint64
+	clampErr        error
+	clampHook       ClampHook
+	units           NumberUnits
+	locale          *NumberLocale
+	chineseNumerals bool
+	validators      []Uint64Validator
+	allowVals       allowList
 }
 
 type Uint64Validator func(paramName string, paramValue uint64) *Error
@@ -20,9 +30,24 @@ func Uint64() *Uint64Filter {
 	return f
 }
 
-// Allow allow value is a string in the specified list
+// Allow allow value is a string in the specified list, it is returned as a
+// Special value like Special("all").
 func (f *Uint64Filter) Allow(vals ...string) *Uint64Filter {
-	f.allowVals = append(f.allowVals, vals...)
+	f.allowVals = f.allowVals.add("", false, vals...)
+	return f
+}
+
+// AllowSpecial allow values in the specified list and return special for
+// them, eg: AllowSpecial(All, "all", "*").
+func (f *Uint64Filter) AllowSpecial(special Special, vals ...string) *Uint64Filter {
+	f.allowVals = f.allowVals.add(special, false, vals...)
+	return f
+}
+
+// AllowSpecialFold is like AllowSpecial but values are matched case
+// insensitively.
+func (f *Uint64Filter) AllowSpecialFold(special Special, vals ...string) *Uint64Filter {
+	f.allowVals = f.allowVals.add(special, true, vals...)
 	return f
 }
 
@@ -35,6 +60,59 @@ func (f *Uint64Filter) Base(base int) *Uint64Filter {
 	return f
 }
 
+// Strict reject underscores, a leading "+", leading zeros and surrounding
+// whitespace, see SetStrictNumbers.
+func (f *Uint64Filter) Strict() *Uint64Filter {
+	f.strict = strictOn
+	return f
+}
+
+// Lenient disable strict mode even if it is enabled package-wide.
+func (f *Uint64Filter) Lenient() *Uint64Filter {
+	f.strict = strictOff
+	return f
+}
+
+// Clamp move param value out of [min, max] onto the nearest limit instead of
+// failing, validators run on the adjusted value.
+func (f *Uint64Filter) Clamp(min, max uint64) *Uint64Filter {
+	f.clamp = true
+	f.clampMin = min
+	f.clampMax = max
+	if min > max {
+		f.clampErr = errInvalidClamp
+	}
+	return f
+}
+
+// OnClamp set a hook called with the original and adjusted value when param
+// value is clamped.
+func (f *Uint64Filter) OnClamp(hook ClampHook) *Uint64Filter {
+	f.clampHook = hook
+	return f
+}
+
+// Units allow values with unit suffixes like "10MB" or "80%", values are
+// normalized to the base unit before validation, see ByteUnits, SIUnits and
+// PercentUnits.
+func (f *Uint64Filter) Units(units ...NumberUnits) *Uint64Filter {
+	f.units = f.units.merge(units...)
+	return f
+}
+
+// Locale accept numbers with the decimal and grouping separators of locale,
+// full-width digits are folded too, eg: "1.234,56" with NumberLocaleDE.
+func (f *Uint64Filter) Locale(locale *NumberLocale) *Uint64Filter {
+	f.locale = locale
+	return f
+}
+
+// ChineseNumerals accept Chinese numerals like "一百二十" and "三点五".
+func (f *Uint64Filter) ChineseNumerals() *Uint64Filter {
+	f.chineseNumerals = true
+	return f
+}
+
 // AddValidator add a custom validator to filter
 func (f *Uint64Filter) AddValidator(validator Uint64Validator) *Uint64Filter {
 	f.validators = append(f.validators, validator)
@@ -127,6 +205,20 @@ func (f *Uint64Filter) In(set []uint64) *Uint64Filter {
 	return f
 }
 
+// clampValue move v onto the nearest limit of clamp range.
+func (f *Uint64Filter) clampValue(paramName string, v uint64) uint64 {
+	adjusted := v
+	if v < f.clampMin {
+		adjusted = f.clampMin
+	} else if v > f.clampMax {
+		adjusted = f.clampMax
+	}
+	if adjusted != v && f.clampHook != nil {
+		f.clampHook(paramName, v, adjusted)
+	}
+	return adjusted
+}
+
 // Run make the filter running.
 func (f *Uint64Filter) Run(paramName string, paramValue interface{}) (interface{}, *Error) {
 	if paramValue == nil {
@@ -136,23 +228,55 @@ func (f *Uint64Filter) Run(paramName string, paramValue interface{}) (interface{
 	var intVal uint64
 	switch val := paramValue.(type) {
 	case string:
+		raw := val
 		val = strings.Trim(val, " \t\r\n")
-		for _, allowVal := range f.allowVals {
-			if allowVal == val {
-				return val, nil
+		if special, ok := f.allowVals.match(val); ok {
+			return special, nil
+		}
+		if word := f.strict.check(raw, f.base, false); word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		if f.locale != nil || f.chineseNumerals {
+			var ok bool
+			if val, ok = normalizeNumber(val, f.locale, f.chineseNumerals); !ok {
+				goto parse_error
 			}
 		}
+		if r, matched, ok := f.units.parse(val); matched {
+			if !ok {
+				goto parse_error
+			}
+			v, word, _ := coerceUint64(r, math.MaxUint64)
+			if word != "" {
+				return nil, NewError(ErrorInvalidParam, paramName, word)
+			}
+			intVal = v
+			break
+		}
 		v, err := strconv.ParseUint(val, f.base, 64)
-		if err != nil {
+		if err != nil && !(f.clamp && isRangeErr(err)) {
 			goto parse_error
 		}
 		intVal = v
 	case uint64:
 		intVal = val
 	default:
-		goto parse_error
+		v, word, ok := coerceUint64(val, math.MaxUint64)
+		if !ok {
+			goto parse_error
+		}
+		if word != "" {
+			return nil, NewError(ErrorInvalidParam, paramName, word)
+		}
+		intVal = v
 	}
 
+	if f.clamp {
+		if f.clampErr != nil {
+			return nil, NewError(ErrorInternalError, paramName, "InvalidValidator")
+		}
+		intVal = f.clampValue(paramName, intVal)
+	}
 	for _, validator := range f.validators {
 		if err := validator(paramName, intVal); err != nil {
 			return nil, err
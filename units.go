@@ -0,0 +1,121 @@
+package filter
+
+import (
+	"math"
+	"math/big"
+	"strings"
+)
+
+// NumberUnits is a set of unit suffixes and their exact multipliers to the
+// base unit.
+type NumberUnits map[string]*big.Rat
+
+// ByteUnits are byte size units in bytes, SI units like "MB" are powers of
+// 1000 and IEC units like "MiB" are powers of 1024.
+var ByteUnits = NumberUnits{
+	"B":   big.NewRat(1, 1),
+	"kB":  ratPow(1000, 1),
+	"KB":  ratPow(1000, 1),
+	"MB":  ratPow(1000, 2),
+	"GB":  ratPow(1000, 3),
+	"TB":  ratPow(1000, 4),
+	"PB":  ratPow(1000, 5),
+	"EB":  ratPow(1000, 6),
+	"KiB": ratPow(1024, 1),
+	"MiB": ratPow(1024, 2),
+	"GiB": ratPow(1024, 3),
+	"TiB": ratPow(1024, 4),
+	"PiB": ratPow(1024, 5),
+	"EiB": ratPow(1024, 6),
+}
+
+// SIUnits are SI magnitude suffixes like "500k" and "2M", suffixes are case
+// sensitive except "K".
+var SIUnits = NumberUnits{
+	"k": ratPow(1000, 1),
+	"K": ratPow(1000, 1),
+	"M": ratPow(1000, 2),
+	"G": ratPow(1000, 3),
+	"T": ratPow(1000, 4),
+	"P": ratPow(1000, 5),
+	"E": ratPow(1000, 6),
+}
+
+// PercentUnits are ratio suffixes, "80%" is 0.8 and "2.5x" is 2.5.
+var PercentUnits = NumberUnits{
+	"%": big.NewRat(1, 100),
+	"x": big.NewRat(1, 1),
+}
+
+// ratPow return base^n as a big.Rat.
+func ratPow(base, n int64) *big.Rat {
+	p := new(big.Int).Exp(big.NewInt(base), big.NewInt(n), nil)
+	return new(big.Rat).SetInt(p)
+}
+
+// merge return a new unit set containing units of u and others.
+func (u NumberUnits) merge(others ...NumberUnits) NumberUnits {
+	merged := make(NumberUnits, len(u))
+	for suffix, m := range u {
+		merged[suffix] = m
+	}
+	for _, units := range others {
+		for suffix, m := range units {
+			merged[suffix] = m
+		}
+	}
+	return merged
+}
+
+// parse parse a decimal number with a unit suffix like "1.5GiB" and return
+// its exact value in the base unit. matched is false if s has no known
+// suffix. Suffixes are matched exactly first, then case insensitively for
+// suffixes of two or more letters, eg: "10mb".
+func (u NumberUnits) parse(s string) (value *big.Rat, matched bool, ok bool) {
+	if len(u) == 0 {
+		return nil, false, false
+	}
+
+	var suffix string
+	for unit := range u {
+		if len(unit) > len(suffix) && strings.HasSuffix(s, unit) {
+			suffix = unit
+		}
+	}
+	if suffix == "" {
+		for unit := range u {
+			if len(unit) >= 2 && len(unit) > len(suffix) && len(s) >= len(unit) &&
+				strings.EqualFold(s[len(s)-len(unit):], unit) {
+				suffix = unit
+			}
+		}
+	}
+	if suffix == "" {
+		return nil, false, false
+	}
+
+	num := strings.TrimRight(s[:len(s)-len(suffix)], " \t")
+	if num == "" {
+		return nil, true, false
+	}
+	r, word, ok := decimalRat(num)
+	if !ok || word != "" {
+		return nil, true, false
+	}
+	return r.Mul(r, u[suffix]), true, true
+}
+
+// ratFloat round r to the nearest float of the specified bit size.
+func ratFloat(r *big.Rat, bitSize int) (float64, string) {
+	var f float64
+	if bitSize == 32 {
+		f32, _ := r.Float32()
+		f = float64(f32)
+	} else {
+		f, _ = r.Float64()
+	}
+	if math.IsInf(f, 0) {
+		return 0, "NumberOverflow"
+	}
+	return f, ""
+}
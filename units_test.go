@@ -0,0 +1,23 @@
+package filter
+
+import "testing"
+
+func TestNumberUnits(t *testing.T) {
+	testRun(t, []runCase{
+		{Int64().Units(ByteUnits), "10MB", "10000000", ""},
+		{Int64().Units(ByteUnits), "1.5GiB", "1610612736", ""},
+		{Int64().Units(ByteUnits), "10mb", "10000000", ""},
+		{Int64().Units(ByteUnits), "1.5B", "", "NumberHasFraction"},
+		{Int64().Units(ByteUnits), "10", "10", ""},
+		{Int64().Units(ByteUnits), "10XB", "", "NotInt64"},
+		{Int64().Units(ByteUnits), "16EiB", "", "NumberOverflow"},
+		{Int64().Units(SIUnits), "500k", "500000", ""},
+		{Int64().Units(SIUnits), "2M", "2000000", ""},
+		{Int64().Units(ByteUnits, SIUnits), "2K", "2000", ""},
+		{Uint64().Units(ByteUnits), "-1KB", "", "NumberOverflow"},
+		{Float64().Units(PercentUnits), "80%", "0.8", ""},
+		{Float64().Units(PercentUnits), "2.5x", "2.5", ""},
+		{Int64().Units(ByteUnits).Max(1000000), "2MB", "", "TooLarge"},
+		{Int64().Units(ByteUnits).Clamp(0, 1000), "1GB", "1000", ""},
+	})
+}